@@ -0,0 +1,196 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// defaultBulkConcurrency is the number of requests in flight of a bulk operation without WithConcurrency
+const defaultBulkConcurrency = 4
+
+// ErrBulkStopped is the error of the items skipped after an earlier item failed with WithStopOnError
+var ErrBulkStopped = errors.New("skipped after an earlier item failed")
+
+// BulkOption configures BulkCreate, BulkUpdate and BulkDelete
+type BulkOption func(*bulkConfig)
+
+type bulkConfig struct {
+	concurrency int
+	stopOnError bool
+}
+
+// WithConcurrency limits the number of requests in flight, 4 by default
+func WithConcurrency(n int) BulkOption {
+	return func(c *bulkConfig) { c.concurrency = n }
+}
+
+// WithStopOnError makes a failed item skip the items not started yet, with ErrBulkStopped.
+// The requests in flight are completed. By default every item is tried.
+func WithStopOnError() BulkOption {
+	return func(c *bulkConfig) { c.stopOnError = true }
+}
+
+// BulkUpdateItem is an item of BulkUpdate
+type BulkUpdateItem struct {
+	ID      string
+	Request v1.PutCommonServiceItemRequest
+}
+
+// BulkResult is the outcome of an item of a bulk operation.
+// The results are in the order of the items.
+type BulkResult struct {
+	// Index is the position of the item in the input
+	Index int
+	// ID is the ID of the created, updated or deleted resource, empty for skipped items and failed creations
+	ID string
+	// CommonServiceItem is the created or updated resource, nil for BulkDelete and failed items
+	CommonServiceItem *v1.CommonServiceItem
+	// Err is the error of the item: the one of the request, ErrBulkStopped or the error of the context when it was skipped
+	Err error
+}
+
+// BulkError reports the items of a bulk operation that failed or were skipped
+type BulkError struct {
+	Total   int
+	Failed  []BulkResult
+	Skipped []BulkResult
+}
+
+func (e *BulkError) Error() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d of %d items failed", len(e.Failed), e.Total)
+	if len(e.Skipped) > 0 {
+		fmt.Fprintf(&buf, ", %d skipped", len(e.Skipped))
+	}
+	for _, r := range e.Failed {
+		fmt.Fprintf(&buf, "; item %d: %v", r.Index, r.Err)
+	}
+	return buf.String()
+}
+
+// Unwrap returns the errors of the failed items, then the reason of the skip
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed)+1)
+	for _, r := range e.Failed {
+		errs = append(errs, r.Err)
+	}
+	if len(e.Skipped) > 0 {
+		errs = append(errs, e.Skipped[0].Err)
+	}
+	return errs
+}
+
+// runBulk runs do for the n items with bounded concurrency, returning a *BulkError unless every item succeeded
+func runBulk(ctx context.Context, n int, opts []BulkOption, do func(ctx context.Context, i int) BulkResult) ([]BulkResult, error) {
+	config := bulkConfig{concurrency: defaultBulkConcurrency}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	results := make([]BulkResult, n)
+	sem := make(chan struct{}, max(config.concurrency, 1))
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	var wg sync.WaitGroup
+
+	skipped := make([]bool, n)
+	for i := range results {
+		results[i].Index = i
+		acquired := false
+		select {
+		case sem <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		case <-stop:
+		}
+		select {
+		case <-stop:
+			results[i].Err = ErrBulkStopped
+		default:
+			results[i].Err = ctx.Err()
+		}
+		if results[i].Err != nil {
+			if acquired {
+				<-sem
+			}
+			skipped[i] = true
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := do(ctx, i)
+			result.Index = i
+			results[i] = result
+			if result.Err != nil && config.stopOnError {
+				stopOnce.Do(func() { close(stop) })
+			}
+		}()
+	}
+	wg.Wait()
+
+	bulkErr := &BulkError{Total: n}
+	for i, r := range results {
+		switch {
+		case skipped[i]:
+			bulkErr.Skipped = append(bulkErr.Skipped, r)
+		case r.Err != nil:
+			bulkErr.Failed = append(bulkErr.Failed, r)
+		}
+	}
+	if len(bulkErr.Failed) == 0 && len(bulkErr.Skipped) == 0 {
+		return results, nil
+	}
+	return results, bulkErr
+}
+
+// BulkCreate creates the resources with api concurrently, returning a result per request in the same order
+// and a *BulkError unless every one was created
+func BulkCreate(ctx context.Context, api CommonServiceItemAPI, requests []v1.PostCommonServiceItemRequest, opts ...BulkOption) ([]BulkResult, error) {
+	return runBulk(ctx, len(requests), opts, func(ctx context.Context, i int) BulkResult {
+		res, err := api.Create(ctx, requests[i])
+		if err != nil {
+			return BulkResult{Err: err}
+		}
+		return BulkResult{ID: res.CommonServiceItem.ID, CommonServiceItem: &res.CommonServiceItem}
+	})
+}
+
+// BulkUpdate updates the resources with api concurrently, returning a result per item in the same order
+// and a *BulkError unless every one was updated
+func BulkUpdate(ctx context.Context, api CommonServiceItemAPI, items []BulkUpdateItem, opts ...BulkOption) ([]BulkResult, error) {
+	return runBulk(ctx, len(items), opts, func(ctx context.Context, i int) BulkResult {
+		res, err := api.Update(ctx, items[i].ID, items[i].Request)
+		if err != nil {
+			return BulkResult{ID: items[i].ID, Err: err}
+		}
+		return BulkResult{ID: items[i].ID, CommonServiceItem: &res.CommonServiceItem}
+	})
+}
+
+// BulkDelete deletes the resources with api concurrently, returning a result per ID in the same order
+// and a *BulkError unless every one was deleted
+func BulkDelete(ctx context.Context, api CommonServiceItemAPI, ids []string, opts ...BulkOption) ([]BulkResult, error) {
+	return runBulk(ctx, len(ids), opts, func(ctx context.Context, i int) BulkResult {
+		return BulkResult{ID: ids[i], Err: api.Delete(ctx, ids[i])}
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkCreate(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	srv := simplenotificationtest.NewServer()
+	t.Cleanup(srv.Close)
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	client := fakeClient(t, srv, func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(time.Millisecond)
+		cont, ok := pull()
+		if !ok {
+			return nil, errors.New("middleware not found error")
+		}
+		return cont(req, pull)
+	})
+	destinationAPI := simplenotification.NewDestinationOp(client)
+
+	requests := make([]v1.PostCommonServiceItemRequest, 30)
+	for i := range requests {
+		requests[i] = fakeDestination(fmt.Sprintf("member%02d", i), fmt.Sprintf("member%02d@example.com", i))
+	}
+	results, err := simplenotification.BulkCreate(ctx, destinationAPI, requests, simplenotification.WithConcurrency(5))
+	assert.NoError(err)
+	assert.Len(results, 30)
+	for i, r := range results {
+		assert.Equal(i, r.Index)
+		assert.NoError(r.Err)
+		assert.NotEmpty(r.ID)
+		assert.Equal(fmt.Sprintf("member%02d", i), r.CommonServiceItem.Name)
+	}
+	assert.LessOrEqual(maxInFlight, 5)
+
+	list, err := destinationAPI.List(ctx)
+	assert.NoError(err)
+	assert.Len(list.CommonServiceItems, 30)
+}
+
+func TestBulkUpdate(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	groupAPI := simplenotification.NewGroupOp(client)
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	created, err := simplenotification.BulkCreate(ctx, groupAPI, []v1.PostCommonServiceItemRequest{fakeGroup("ops", dest), fakeGroup("dev", dest)})
+	assert.NoError(err)
+
+	rename := func(name string) v1.PutCommonServiceItemRequest {
+		return v1.PutCommonServiceItemRequest{CommonServiceItem: v1.PutCommonServiceItemRequestCommonServiceItem{
+			Name: name,
+			Tags: []string{},
+			Settings: v1.NewOptPutCommonServiceItemRequestCommonServiceItemSettings(v1.PutCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemGroupSettings: v1.CommonServiceItemGroupSettings{Destinations: []string{dest}},
+			}),
+		}}
+	}
+	results, err := simplenotification.BulkUpdate(ctx, groupAPI, []simplenotification.BulkUpdateItem{
+		{ID: created[0].ID, Request: rename("ops-renamed")},
+		{ID: "123456789012", Request: rename("missing")},
+		{ID: created[1].ID, Request: rename("dev-renamed")},
+	})
+	var bulkErr *simplenotification.BulkError
+	assert.ErrorAs(err, &bulkErr)
+	assert.Len(bulkErr.Failed, 1)
+	assert.Equal(1, bulkErr.Failed[0].Index)
+	assert.True(saclient.IsNotFoundError(err))
+	assert.Equal("ops-renamed", results[0].CommonServiceItem.Name)
+	assert.Equal("123456789012", results[1].ID)
+	assert.Equal("dev-renamed", results[2].CommonServiceItem.Name)
+}
+
+func TestBulkDelete_StopOnError(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	var ids []string
+	for i := range 3 {
+		ids = append(ids, mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+			return destinationAPI.Create(ctx, fakeDestination(fmt.Sprintf("member%d", i), "member@example.com"))
+		}))
+	}
+
+	// continue on error by default
+	results, err := simplenotification.BulkDelete(ctx, destinationAPI, []string{ids[0], "123456789012", ids[1]})
+	var bulkErr *simplenotification.BulkError
+	assert.ErrorAs(err, &bulkErr)
+	assert.Len(bulkErr.Failed, 1)
+	assert.Empty(bulkErr.Skipped)
+	assert.NoError(results[0].Err)
+	assert.True(saclient.IsNotFoundError(results[1].Err))
+	assert.NoError(results[2].Err)
+
+	results, err = simplenotification.BulkDelete(ctx, destinationAPI, []string{"123456789012", ids[2]},
+		simplenotification.WithConcurrency(1), simplenotification.WithStopOnError())
+	assert.ErrorAs(err, &bulkErr)
+	assert.Len(bulkErr.Failed, 1)
+	assert.Len(bulkErr.Skipped, 1)
+	assert.ErrorIs(results[1].Err, simplenotification.ErrBulkStopped)
+	_, err = destinationAPI.Read(ctx, ids[2])
+	assert.NoError(err)
+}
+
+func TestBulkDelete_Canceled(t *testing.T) {
+	assert := require.New(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, client := fakeSetup(t)
+
+	results, err := simplenotification.BulkDelete(ctx, simplenotification.NewRoutingOp(client), []string{"1", "2"})
+	assert.ErrorIs(err, context.Canceled)
+	var bulkErr *simplenotification.BulkError
+	assert.ErrorAs(err, &bulkErr)
+	assert.Len(bulkErr.Skipped, 2)
+	for i, r := range results {
+		assert.Equal(i, r.Index)
+		assert.ErrorIs(r.Err, context.Canceled)
+	}
+}
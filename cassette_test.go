@@ -0,0 +1,149 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sacloud/packages-go/testutil"
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+)
+
+// replayEnvs are the values of the test environment variables used while replaying a cassette.
+// Request bodies are not matched on replay, so they only have to be well-formed.
+var replayEnvs = map[string]string{
+	"SAKURA_DESTINATION_TEST_MAILADDRESS": "redacted@example.com",
+	"SAKURA_DESTINATION_TEST_ID":          "113700000001",
+	"SAKURA_ROUTING_TEST_LABEL":           "env",
+	"SAKURA_ROUTING_TEST_LABELVAL":        "test",
+	"SAKURA_ROUTING_TEST_SOURCEID":        "1",
+	"SAKURA_ROUTING_TEST_TARGETGROUPID":   "113700000002",
+}
+
+// apiPath is the path of the API root in the default zone, under which the fake serves while recording
+// so that the cassettes match the requests of the real client on replay
+const apiPath = "/cloud/zone/is1a/api/cloud/1.1"
+
+// newTestClient returns a client for the integration tests.
+//
+// With SAKURA_ACCESS_TOKEN set, the test talks to the real API and records the interactions into
+// testdata/<test name>.json. With SIMPLENOTIFICATION_RECORD=fake, it records them against a
+// simplenotificationtest.Server holding the resources of replayEnvs into testdata/fake/<test name>.json instead.
+// The fake-server fixtures only tell that the client and the fake agree, they are not recordings of the real API.
+// Otherwise the recording of the real API is replayed, or the fake-server fixture when there is none,
+// and the test fails when neither has been recorded.
+func newTestClient(t *testing.T, envs ...string) *v1.Client {
+	t.Helper()
+	name := strings.ReplaceAll(t.Name(), "/", "_") + ".json"
+	path := filepath.Join("testdata", name)
+	fakePath := filepath.Join("testdata", "fake", name)
+
+	var saClient saclient.Client
+	var rec *simplenotificationtest.Recorder
+	var err error
+	apiRootURL := ""
+	switch {
+	case os.Getenv("SAKURA_ACCESS_TOKEN") != "":
+		testutil.PreCheckEnvsFunc(append([]string{"SAKURA_ACCESS_TOKEN", "SAKURA_ACCESS_TOKEN_SECRET"}, envs...)...)(t)
+		rec = newRecorder(t, path)
+	case os.Getenv("SIMPLENOTIFICATION_RECORD") == "fake":
+		apiRootURL = fakeRecordingServer(t) + apiPath + "/"
+		rec = newRecorder(t, fakePath)
+		for _, env := range envs {
+			t.Setenv(env, replayEnvs[env])
+		}
+		if err := saClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}); err != nil {
+			t.Fatalf("failed to configure client: %v", err)
+		}
+	default:
+		rec, err = simplenotificationtest.NewRecorder(path, simplenotificationtest.ModeReplay)
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Logf("replaying the fake-server fixture %s, not a recording of the real API", fakePath)
+			rec, err = simplenotificationtest.NewRecorder(fakePath, simplenotificationtest.ModeReplay)
+		}
+		if err != nil {
+			t.Fatalf("failed to load cassette: %v; record it with SAKURA_ACCESS_TOKEN or SIMPLENOTIFICATION_RECORD=fake", err)
+		}
+		for _, env := range envs {
+			t.Setenv(env, replayEnvs[env])
+		}
+		if err := saClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}); err != nil {
+			t.Fatalf("failed to configure client: %v", err)
+		}
+	}
+	if err := saClient.SetWith(saclient.WithMiddleware(rec.Middleware())); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+
+	var client *v1.Client
+	if apiRootURL != "" {
+		client, err = simplenotification.NewClientWithAPIRootURL(&saClient, apiRootURL)
+	} else {
+		client, err = simplenotification.NewClient(&saClient)
+	}
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+// newRecorder returns a Recorder saving the cassette at path when the test ends
+func newRecorder(t *testing.T, path string) *simplenotificationtest.Recorder {
+	t.Helper()
+	rec, err := simplenotificationtest.NewRecorder(path, simplenotificationtest.ModeRecord)
+	if err != nil {
+		t.Fatalf("failed to create recorder: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := rec.Save(); err != nil {
+			t.Errorf("failed to save cassette: %v", err)
+		}
+	})
+	return rec
+}
+
+// fakeRecordingServer starts a fake holding the destination and the group of replayEnvs, and a message
+// sent to the group for the history, and returns the URL serving it under apiPath
+func fakeRecordingServer(t *testing.T) string {
+	t.Helper()
+	srv, client := fakeSetup(t)
+	ctx := t.Context()
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("recording-destination", replayEnvs["SAKURA_DESTINATION_TEST_MAILADDRESS"]))
+	})
+	groupAPI := simplenotification.NewGroupOp(client)
+	group := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("recording-group", dest))
+	})
+	if dest != replayEnvs["SAKURA_DESTINATION_TEST_ID"] || group != replayEnvs["SAKURA_ROUTING_TEST_TARGETGROUPID"] {
+		t.Fatalf("fake resources %s and %s do not match replayEnvs", dest, group)
+	}
+	if _, err := groupAPI.SendMessage(ctx, group, v1.SendNotificationMessageRequest{Message: "recording"}); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+	prefixed := httptest.NewServer(http.StripPrefix(apiPath, srv.Config.Handler))
+	t.Cleanup(prefixed.Close)
+	return prefixed.URL
+}
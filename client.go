@@ -15,24 +15,83 @@
 package simplenotification
 
 import (
+	"errors"
+
+	ht "github.com/ogen-go/ogen/http"
 	"github.com/sacloud/saclient-go"
 	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
 )
 
-const (
-	defaultAPIRootURL = "https://secure.sakura.ad.jp/cloud/zone/is1a/api/cloud/1.1/"
-)
+// ClientOption configures a client created by NewClient and NewClientWithAPIRootURL
+type ClientOption func(*clientConfig)
+
+type clientConfig struct {
+	strict                 bool
+	zone                   string
+	fallbackZones          []string
+	retryPolicy            RetryPolicy
+	operationRetryPolicies map[v1.OperationName]RetryPolicy
+}
+
+func newClientConfig(opts []ClientOption) clientConfig {
+	config := clientConfig{retryPolicy: DefaultRetryPolicy}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
 
-// NewClient creates a new simple-notification API client with default settings
-func NewClient(client *saclient.Client) (*v1.Client, error) {
-	return NewClientWithAPIRootURL(client, defaultAPIRootURL)
+// WithStrictDecoding makes a response with an unknown enum value, such as a new destination type, fail to decode.
+// By default such values are kept as raw strings and reported by IsUnknown, so that one new resource does not break a whole list.
+func WithStrictDecoding() ClientOption {
+	return func(c *clientConfig) { c.strict = true }
+}
+
+// NewClient creates a new simple-notification API client with default settings.
+// The endpoint is the one of the zone given by WithZone, SAKURA_ZONE or the profile, is1a by default.
+// client is not modified and can be shared with other SDKs and other calls of NewClient.
+func NewClient(client *saclient.Client, opts ...ClientOption) (*v1.Client, error) {
+	config := newClientConfig(opts)
+	client, err := isolatedClient(client, config)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := client.EndpointConfig()
+	if err != nil {
+		return nil, err
+	}
+	rootURLs, err := config.zoneAPIRootURLs(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var doer ht.Client = client
+	if len(rootURLs) > 1 {
+		doer = &failoverClient{client: client, rootURLs: rootURLs}
+	}
+	return v1.NewClient(rootURLs[0], v1.WithClient(newRetryClient(doer, config)))
+}
+
+// NewClientWithAPIRootURL creates a new simple-notification API client with a custom API root URL.
+// Like NewClient, it does not modify client.
+func NewClientWithAPIRootURL(client *saclient.Client, apiRootURL string, opts ...ClientOption) (*v1.Client, error) {
+	config := newClientConfig(opts)
+	if config.zone != "" || len(config.fallbackZones) > 0 {
+		return nil, errors.New("WithZone and WithFallbackZones cannot be used with an API root URL")
+	}
+	client, err := isolatedClient(client, config)
+	if err != nil {
+		return nil, err
+	}
+	return v1.NewClient(apiRootURL, v1.WithClient(newRetryClient(client, config)))
 }
 
-// NewClientWithAPIRootURL creates a new simple-notification API client with a custom API root URL
-func NewClientWithAPIRootURL(client *saclient.Client, apiRootURL string) (*v1.Client, error) {
-	err := client.SetWith(saclient.WithBigInt(false), saclient.WithMiddleware(modifiyMiddleware()))
+// isolatedClient derives a copy of client with the settings of this API, so that the caller's client,
+// possibly shared with other SAKURA Cloud SDKs, is left untouched. The copy keeps the settings and middlewares
+// of client but has its own rate limiter, and leaves retries to retryClient.
+func isolatedClient(client *saclient.Client, config clientConfig) (*saclient.Client, error) {
+	dup, err := client.DupWith(saclient.WithBigInt(false), saclient.WithoutRetry(), saclient.WithMiddleware(modifiyMiddleware(config)))
 	if err != nil {
 		return nil, err
 	}
-	return v1.NewClient(apiRootURL, v1.WithClient(client))
+	return dup.(*saclient.Client), nil
 }
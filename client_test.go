@@ -15,6 +15,11 @@
 package simplenotification_test
 
 import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/sacloud/saclient-go"
@@ -30,3 +35,168 @@ func TestNewClient(t *testing.T) {
 	assert.NoError(err)
 	assert.NotNil(actual)
 }
+
+// stubTransport answers every request without network access, failing with a dial error for unreachable zones
+func stubTransport(urls *[]string, unreachable ...string) saclient.Middleware {
+	return func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		*urls = append(*urls, req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+		for _, zone := range unreachable {
+			if strings.Contains(req.URL.Path, "/"+zone+"/") {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+			}
+		}
+		body := `{"From":0,"Count":0,"Total":0,"CommonServiceItems":[]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+}
+
+func TestNewClient_Zone(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ []string
+		opts    []simplenotification.ClientOption
+		want    string
+		wantErr string
+	}{
+		{
+			name: "default",
+			want: "https://secure.sakura.ad.jp/cloud/zone/is1a/api/cloud/1.1/commonserviceitem",
+		},
+		{
+			name: "WithZone",
+			opts: []simplenotification.ClientOption{simplenotification.WithZone("tk1a")},
+			want: "https://secure.sakura.ad.jp/cloud/zone/tk1a/api/cloud/1.1/commonserviceitem",
+		},
+		{
+			name:    "SAKURA_ZONE",
+			environ: []string{"SAKURA_ZONE=is1b"},
+			want:    "https://secure.sakura.ad.jp/cloud/zone/is1b/api/cloud/1.1/commonserviceitem",
+		},
+		{
+			name:    "WithZone overrides SAKURA_ZONE",
+			environ: []string{"SAKURA_ZONE=is1b"},
+			opts:    []simplenotification.ClientOption{simplenotification.WithZone("tk1b")},
+			want:    "https://secure.sakura.ad.jp/cloud/zone/tk1b/api/cloud/1.1/commonserviceitem",
+		},
+		{
+			name:    "SAKURA_API_ROOT_URL",
+			environ: []string{"SAKURA_API_ROOT_URL=https://api.example.com/cloud/zone/", "SAKURA_ZONE=tk1v"},
+			want:    "https://api.example.com/cloud/zone/tk1v/api/cloud/1.1/commonserviceitem",
+		},
+		{
+			name:    "unknown zone",
+			opts:    []simplenotification.ClientOption{simplenotification.WithZone("xx9z")},
+			wantErr: `unknown zone "xx9z"`,
+		},
+		{
+			name:    "unknown SAKURA_ZONE",
+			environ: []string{"SAKURA_ZONE=xx9z"},
+			wantErr: `unknown zone "xx9z"`,
+		},
+		{
+			name:    "unknown fallback zone",
+			opts:    []simplenotification.ClientOption{simplenotification.WithFallbackZones("tk1a", "xx9z")},
+			wantErr: `unknown zone "xx9z"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			var urls []string
+			var saClient saclient.Client
+			assert.NoError(saClient.SetEnviron(tt.environ))
+			assert.NoError(saClient.SetWith(saclient.WithMiddleware(stubTransport(&urls))))
+
+			client, err := simplenotification.NewClient(&saClient, tt.opts...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(err, tt.wantErr)
+				return
+			}
+			assert.NoError(err)
+			_, err = simplenotification.NewDestinationOp(client).List(t.Context())
+			assert.NoError(err)
+			assert.Equal([]string{tt.want}, urls)
+		})
+	}
+}
+
+func TestNewClient_FallbackZones(t *testing.T) {
+	assert := require.New(t)
+	var urls []string
+	var saClient saclient.Client
+	assert.NoError(saClient.SetEnviron(nil))
+	assert.NoError(saClient.SetWith(saclient.WithMiddleware(stubTransport(&urls, "is1a", "tk1a"))))
+
+	client, err := simplenotification.NewClient(&saClient, simplenotification.WithFallbackZones("tk1a", "is1b"))
+	assert.NoError(err)
+	_, err = simplenotification.NewDestinationOp(client).List(t.Context())
+	assert.NoError(err)
+	assert.Equal([]string{
+		"https://secure.sakura.ad.jp/cloud/zone/is1a/api/cloud/1.1/commonserviceitem",
+		"https://secure.sakura.ad.jp/cloud/zone/tk1a/api/cloud/1.1/commonserviceitem",
+		"https://secure.sakura.ad.jp/cloud/zone/is1b/api/cloud/1.1/commonserviceitem",
+	}, urls)
+
+	// without a reachable zone the error of the last one is returned
+	urls = nil
+	client, err = simplenotification.NewClient(&saClient, simplenotification.WithFallbackZones("tk1a"),
+		simplenotification.WithRetryPolicy(simplenotification.RetryPolicy{MaxAttempts: 1}))
+	assert.NoError(err)
+	_, err = simplenotification.NewDestinationOp(client).List(t.Context())
+	assert.ErrorContains(err, "connection refused")
+	assert.Len(urls, 2)
+}
+
+func TestNewClientWithAPIRootURL_Zone(t *testing.T) {
+	var saClient saclient.Client
+	_, err := simplenotification.NewClientWithAPIRootURL(&saClient, "https://api.example.com/", simplenotification.WithZone("tk1a"))
+	require.Error(t, err)
+}
+
+func TestNewClient_SharedClient(t *testing.T) {
+	assert := require.New(t)
+	var queries []string
+	var saClient saclient.Client
+	assert.NoError(saClient.SetEnviron(nil))
+	assert.NoError(saClient.SetWith(saclient.WithMiddleware(func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		queries = append(queries, req.URL.RawQuery)
+		body := `{"From":0,"Count":0,"Total":0,"CommonServiceItems":[]}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})))
+
+	// repeated construction from the same client works and does not stack the settings
+	for range 3 {
+		client, err := simplenotification.NewClient(&saClient)
+		assert.NoError(err)
+		_, err = simplenotification.NewDestinationOp(client).List(t.Context())
+		assert.NoError(err)
+	}
+	client, err := simplenotification.NewClientWithAPIRootURL(&saClient, "https://api.example.com/")
+	assert.NoError(err)
+	_, err = simplenotification.NewGroupOp(client).List(t.Context())
+	assert.NoError(err)
+	assert.Len(queries, 4)
+	for _, query := range queries {
+		assert.Equal(1, strings.Count(query, "Filter"), query)
+	}
+
+	// the caller's client is neither populated nor modified
+	assert.NoError(saClient.SetWith(saclient.WithUserAgent("another-sdk")))
+	queries = nil
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://api.example.com/commonserviceitem", nil)
+	assert.NoError(err)
+	resp, err := saClient.Do(req)
+	assert.NoError(err)
+	assert.NoError(resp.Body.Close())
+	assert.Equal([]string{""}, queries)
+}
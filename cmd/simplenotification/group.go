@@ -0,0 +1,88 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+func (c *cli) groupCheck(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("group check", flag.ContinueOnError)
+	id := fs.String("id", "", "ID of the group to check, every group when empty")
+	criticalTag := fs.String("critical-tag", "paging-critical", "tag of the paging-critical groups, every group being critical when empty")
+	strict := fs.Bool("strict", false, "fail for a degraded paging-critical group too, not only an unreachable one")
+	if err := c.parse(fs, args); err != nil {
+		return err
+	}
+
+	client, err := c.newClient()
+	if err != nil {
+		return err
+	}
+	groupAPI := simplenotification.NewGroupOp(client)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	list, err := groupAPI.List(ctx)
+	if err != nil {
+		return err
+	}
+	groups := list.CommonServiceItems
+	if *id != "" {
+		i := slices.IndexFunc(groups, func(group v1.CommonServiceItem) bool { return group.ID == *id })
+		if i < 0 {
+			return fmt.Errorf("group %s is not found", *id)
+		}
+		groups = groups[i : i+1]
+	}
+
+	// only the paging-critical groups fail the check, the others are reported as warnings
+	var failures []error
+	for _, group := range groups {
+		critical := *criticalTag == "" || slices.Contains(group.Tags, *criticalTag)
+		d, err := simplenotification.CheckDeliverability(ctx, groupAPI, destinationAPI, group.ID)
+		// the destinations that failed are in the verdict of their group, any other error ends the check
+		if err != nil {
+			var bulkErr *simplenotification.BulkError
+			if !errors.As(err, &bulkErr) {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		label := ""
+		if critical {
+			label = ", paging-critical"
+		}
+		fmt.Fprintf(c.stdout, "group %s (%s%s): %s\n", d.GroupID, d.GroupName, label, d.Verdict)
+		for _, reason := range d.Reasons {
+			fmt.Fprintf(c.stdout, "  %s\n", reason)
+		}
+		failing := d.Verdict == simplenotification.VerdictUnreachable || (*strict && d.Verdict == simplenotification.VerdictDegraded)
+		switch {
+		case failing && critical:
+			failures = append(failures, fmt.Errorf("group %s is %s", d.GroupID, d.Verdict))
+		case d.Verdict == simplenotification.VerdictUnreachable:
+			fmt.Fprintf(c.stderr, "warning: group %s is unreachable, not failing as it is not paging-critical\n", d.GroupID)
+		}
+	}
+	return errors.Join(failures...)
+}
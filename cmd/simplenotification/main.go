@@ -0,0 +1,115 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command simplenotification operates the simple-notification API from the command line.
+//
+// The credentials are read from the environment and the profile like the other sacloud tools:
+//
+//	simplenotification history export -format csv -since 2026-04-01T00:00:00+09:00 > april.csv
+//	simplenotification group check -critical-tag paging-critical
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+const usage = `usage: simplenotification <command> <subcommand> [flags]
+
+commands:
+  group check      tell whether the groups can reach their destinations, failing when a paging-critical one reaches none
+  history export   write the latest notification histories as CSV or JSON Lines, a row per delivery
+`
+
+// exitUsage is the exit code of invalid arguments
+const exitUsage = 2
+
+// cli is a run of the command with its output and the client, replaced by the tests
+type cli struct {
+	stdout, stderr io.Writer
+	newClient      func() (*v1.Client, error)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	c := &cli{stdout: os.Stdout, stderr: os.Stderr, newClient: newClient}
+	code := c.run(ctx, os.Args[1:])
+	stop()
+	os.Exit(code)
+}
+
+func newClient() (*v1.Client, error) {
+	var sa saclient.Client
+	if err := sa.SetEnviron(os.Environ()); err != nil {
+		return nil, err
+	}
+	return simplenotification.NewClient(&sa)
+}
+
+// run runs a command and returns the exit code
+func (c *cli) run(ctx context.Context, args []string) int {
+	if len(args) < 2 {
+		fmt.Fprint(c.stderr, usage)
+		return exitUsage
+	}
+	var err error
+	switch args[0] + " " + args[1] {
+	case "group check":
+		err = c.groupCheck(ctx, args[2:])
+	case "history export":
+		err = c.historyExport(ctx, args[2:])
+	default:
+		fmt.Fprintf(c.stderr, "unknown command %q\n%s", args[0]+" "+args[1], usage)
+		return exitUsage
+	}
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return exitUsage
+	case err != nil:
+		fmt.Fprintf(c.stderr, "simplenotification %s %s: %v\n", args[0], args[1], err)
+		return 1
+	}
+	return 0
+}
+
+// errUsage is returned by the commands for invalid flags, already reported by the flag set
+var errUsage = errors.New("invalid usage")
+
+// parse parses the flags of a command, reporting errors on stderr
+func (c *cli) parse(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(c.stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(c.stderr, "unexpected arguments: %v\n", fs.Args())
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
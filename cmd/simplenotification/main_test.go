@@ -0,0 +1,213 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCLI returns a cli talking to a fake server, with its output
+func fakeCLI(t *testing.T, opts ...simplenotificationtest.Option) (*cli, *simplenotificationtest.Server, *v1.Client, *bytes.Buffer, *bytes.Buffer) {
+	srv := simplenotificationtest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+	var sa saclient.Client
+	if err := sa.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+	client, err := simplenotification.NewClientWithAPIRootURL(&sa, srv.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	var stdout, stderr bytes.Buffer
+	c := &cli{stdout: &stdout, stderr: &stderr, newClient: func() (*v1.Client, error) { return client, nil }}
+	return c, srv, client, &stdout, &stderr
+}
+
+func TestRun_Usage(t *testing.T) {
+	assert := require.New(t)
+	c, _, _, _, stderr := fakeCLI(t)
+	assert.Equal(exitUsage, c.run(t.Context(), nil))
+	assert.Contains(stderr.String(), "usage: simplenotification")
+	assert.Equal(exitUsage, c.run(t.Context(), []string{"history", "delete"}))
+	assert.Contains(stderr.String(), `unknown command "history delete"`)
+	assert.Equal(exitUsage, c.run(t.Context(), []string{"history", "export", "-colour"}))
+}
+
+func TestRun_HistoryExport(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	now := time.Date(2026, 3, 31, 14, 30, 0, 0, time.UTC)
+	c, _, client, stdout, stderr := fakeCLI(t, simplenotificationtest.WithClock(func() time.Time { return now }))
+
+	dest, err := simplenotification.NewDestinationOp(client).Create(ctx, v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: "oncall",
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemDestinationSettings: v1.CommonServiceItemDestinationSettings{Type: v1.CommonServiceItemDestinationSettingsTypeEmail, Value: "oncall@example.com"},
+			},
+		},
+	})
+	assert.NoError(err)
+	groupAPI := simplenotification.NewGroupOp(client)
+	group, err := groupAPI.Create(ctx, v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: "ops",
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemGroupSettings: v1.CommonServiceItemGroupSettings{Destinations: []string{dest.CommonServiceItem.ID}},
+			},
+		},
+	})
+	assert.NoError(err)
+	for _, message := range []string{"march", "april"} {
+		_, err := groupAPI.SendMessage(ctx, group.CommonServiceItem.ID, v1.SendNotificationMessageRequest{Message: message})
+		assert.NoError(err)
+		now = now.Add(time.Hour)
+	}
+
+	code := c.run(ctx, []string{"history", "export", "-since", "2026-04-01T00:00:00+09:00"})
+	assert.Equal(0, code, stderr.String())
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	assert.Len(lines, 2)
+	assert.True(strings.HasPrefix(lines[0], "request_id,source_id,source_name,"))
+	assert.Contains(lines[1], ",2026-04-01T00:30:00+09:00,,april,")
+	assert.Contains(lines[1], ",ops,")
+	assert.Contains(lines[1], ",oncall,email,")
+
+	stdout.Reset()
+	code = c.run(ctx, []string{"history", "export", "-format", "ndjson", "-tz", "UTC"})
+	assert.Equal(0, code, stderr.String())
+	assert.Equal(2, strings.Count(stdout.String(), "\n"))
+	assert.Contains(stdout.String(), `"received_at":"2026-03-31T14:30:00Z"`)
+
+	assert.Equal(1, c.run(ctx, []string{"history", "export", "-format", "xml"}))
+	assert.Contains(stderr.String(), `simplenotification history export: unsupported format "xml"`)
+}
+
+func TestRun_GroupCheck(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	c, srv, client, stdout, stderr := fakeCLI(t)
+
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	var destinations []string
+	for _, name := range []string{"alice", "bob"} {
+		dest, err := destinationAPI.Create(ctx, v1.PostCommonServiceItemRequest{
+			CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+				Name: name,
+				Tags: []string{},
+				Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+					CommonServiceItemDestinationSettings: v1.CommonServiceItemDestinationSettings{Type: v1.CommonServiceItemDestinationSettingsTypeEmail, Value: name + "@example.com"},
+				},
+			},
+		})
+		assert.NoError(err)
+		destinations = append(destinations, dest.CommonServiceItem.ID)
+	}
+	createGroup := func(name string, tags []string, destinations ...string) string {
+		group, err := simplenotification.NewGroupOp(client).Create(ctx, v1.PostCommonServiceItemRequest{
+			CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+				Name: name,
+				Tags: tags,
+				Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+					CommonServiceItemGroupSettings: v1.CommonServiceItemGroupSettings{Destinations: destinations},
+				},
+			},
+		})
+		assert.NoError(err)
+		return group.CommonServiceItem.ID
+	}
+	id := createGroup("ops", []string{"paging-critical"}, destinations...)
+	dev := createGroup("dev", []string{}, destinations[1])
+
+	assert.Equal(0, c.run(ctx, []string{"group", "check", "-id", id}), stderr.String())
+	assert.Equal("group "+id+" (ops, paging-critical): reachable\n", stdout.String())
+
+	srv.SetDestinationValid(destinations[1], false)
+	stdout.Reset()
+	assert.Equal(0, c.run(ctx, []string{"group", "check", "-id", id}), stderr.String())
+	assert.Contains(stdout.String(), "(ops, paging-critical): degraded\n  destination "+destinations[1]+" (bob) is not valid\n")
+	assert.Equal(1, c.run(ctx, []string{"group", "check", "-id", id, "-strict"}))
+	assert.Contains(stderr.String(), "simplenotification group check: group "+id+" is degraded")
+
+	// an unreachable group not paging-critical is only a warning
+	stdout.Reset()
+	stderr.Reset()
+	assert.Equal(0, c.run(ctx, []string{"group", "check"}), stderr.String())
+	assert.Contains(stdout.String(), "group "+dev+" (dev): unreachable\n")
+	assert.Equal("warning: group "+dev+" is unreachable, not failing as it is not paging-critical\n", stderr.String())
+	assert.Equal(1, c.run(ctx, []string{"group", "check", "-id", dev, "-critical-tag", ""}))
+	assert.Contains(stderr.String(), "group "+dev+" is unreachable")
+
+	srv.SetDestinationValid(destinations[0], false)
+	stderr.Reset()
+	assert.Equal(1, c.run(ctx, []string{"group", "check"}))
+	assert.Contains(stderr.String(), "group "+id+" is unreachable")
+	assert.Contains(stderr.String(), "warning: group "+dev+" is unreachable")
+	assert.NotContains(stderr.String(), "\ngroup "+dev)
+
+	assert.Equal(1, c.run(ctx, []string{"group", "check", "-id", "999999999999"}))
+	assert.Contains(stderr.String(), "group 999999999999 is not found")
+	assert.Equal(exitUsage, c.run(ctx, []string{"group", "check", "ops"}))
+	assert.Contains(stderr.String(), "unexpected arguments")
+
+	// a destination failing does not stop the check, and fails it only through a paging-critical group
+	srv.SetDestinationValid(destinations[0], true)
+	srv.SetDestinationValid(destinations[1], true)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/commonserviceitem/"+destinations[1]+"/simplenotification/status" {
+			http.Error(w, `{"is_fatal":true,"status":"403 Forbidden","error_code":"forbidden","error_msg":"forbidden"}`, http.StatusForbidden)
+			return
+		}
+		httputil.NewSingleHostReverseProxy(mustParseURL(t, srv.URL)).ServeHTTP(w, r)
+	}))
+	t.Cleanup(failing.Close)
+	var sa saclient.Client
+	assert.NoError(sa.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}))
+	failingClient, err := simplenotification.NewClientWithAPIRootURL(&sa, failing.URL)
+	assert.NoError(err)
+	c.newClient = func() (*v1.Client, error) { return failingClient, nil }
+	stdout.Reset()
+	stderr.Reset()
+	assert.Equal(0, c.run(ctx, []string{"group", "check"}), stderr.String())
+	assert.Contains(stdout.String(), "group "+id+" (ops, paging-critical): degraded\n  destination "+destinations[1]+" failed: ")
+	assert.Contains(stdout.String(), "group "+dev+" (dev): unreachable\n  destination "+destinations[1]+" failed: ")
+	assert.Equal("warning: group "+dev+" is unreachable, not failing as it is not paging-critical\n", stderr.String())
+	assert.Equal(1, c.run(ctx, []string{"group", "check", "-strict"}))
+	assert.Contains(stderr.String(), "group "+id+" is degraded")
+}
+
+func mustParseURL(t *testing.T, rawURL string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("invalid URL %q: %v", rawURL, err)
+	}
+	return u
+}
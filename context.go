@@ -16,6 +16,7 @@ package simplenotification
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
@@ -24,20 +25,40 @@ import (
 // contextKey is a custom type for context keys in this package
 type contextKey string
 
-const providerClassKey contextKey = "Provider.Class"
+const (
+	providerClassKey contextKey = "Provider.Class"
+	rawResponseKey   contextKey = "RawResponse"
+)
 
 func setContextProviderClass(ctx context.Context, providerClass v1.CommonServiceItemProviderClass) context.Context {
 	return context.WithValue(ctx, providerClassKey, providerClass)
 }
 
+// errProviderClassNotSet is returned by getContextProviderClass for a context without Provider.Class
+var errProviderClassNotSet = errors.New("Provider.Class not found in context")
+
 func getContextProviderClass(ctx context.Context) (v1.CommonServiceItemProviderClass, error) {
 	v := ctx.Value(providerClassKey)
 	if v == nil {
-		return "", fmt.Errorf("Provider.Class not found in context")
+		return "", errProviderClassNotSet
 	}
 	s, ok := v.(v1.CommonServiceItemProviderClass)
 	if !ok {
 		return "", fmt.Errorf("Provider.Class is not a string")
 	}
+	if err := s.Validate(); err != nil {
+		return "", fmt.Errorf("invalid Provider.Class: %w", err)
+	}
 	return s, nil
 }
+
+// setContextRawResponse asks the middleware to keep the response body for the lenient decode
+func setContextRawResponse(ctx context.Context) (context.Context, *rawResponse) {
+	raw := &rawResponse{}
+	return context.WithValue(ctx, rawResponseKey, raw), raw
+}
+
+func getContextRawResponse(ctx context.Context) *rawResponse {
+	raw, _ := ctx.Value(rawResponseKey).(*rawResponse)
+	return raw
+}
@@ -0,0 +1,123 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sacloud/saclient-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// Verdict tells whether a group can reach its destinations
+type Verdict string
+
+const (
+	// VerdictReachable is a group whose destinations are all healthy
+	VerdictReachable Verdict = "reachable"
+	// VerdictDegraded is a group with some of its destinations not healthy
+	VerdictDegraded Verdict = "degraded"
+	// VerdictUnreachable is a group disabled, without destinations or with none of them healthy
+	VerdictUnreachable Verdict = "unreachable"
+)
+
+// Deliverability is the verdict of CheckDeliverability for a group, with the reasons of a verdict other than reachable
+type Deliverability struct {
+	GroupID   string
+	GroupName string
+	// GroupDisabled is the Disabled flag of the group, which makes it unreachable whatever its destinations
+	GroupDisabled bool
+	Verdict       Verdict
+	// Destinations are the destinations of the group in its order, with the error of a destination not found
+	Destinations []DestinationHealth
+	Reasons      []string
+}
+
+// CheckDeliverability reads the group with groupAPI and every one of its destinations with destinationAPI,
+// gets their status in parallel and tells whether the group can reach them. An id of an item other than a group
+// is an error. The destinations not found or failing are reported in the reasons, and the verdict comes with
+// a *BulkError of the destinations that failed or were not checked.
+func CheckDeliverability(ctx context.Context, groupAPI GroupAPI, destinationAPI DestinationAPI, id string, opts ...BulkOption) (*Deliverability, error) {
+	group, err := groupAPI.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if class := group.CommonServiceItem.Provider.Class; class != v1.CommonServiceItemProviderClassSaknoticegroup {
+		return nil, NewError("CheckDeliverability", fmt.Errorf("item %s is a %s, not a group", id, class))
+	}
+	settings := group.CommonServiceItem.Settings.CommonServiceItemGroupSettings
+	d := &Deliverability{
+		GroupID:       id,
+		GroupName:     group.CommonServiceItem.Name,
+		GroupDisabled: settings.Disabled.Or(false),
+		Destinations:  make([]DestinationHealth, len(settings.Destinations)),
+	}
+	results, bulkErr := runBulk(ctx, len(settings.Destinations), opts, func(ctx context.Context, i int) BulkResult {
+		h := &d.Destinations[i]
+		h.ID = settings.Destinations[i]
+		dest, err := destinationAPI.Read(ctx, h.ID)
+		if err != nil {
+			return BulkResult{ID: h.ID, Err: err}
+		}
+		h.Name = dest.CommonServiceItem.Name
+		h.Type = dest.CommonServiceItem.Settings.CommonServiceItemDestinationSettings.Type
+		h.Disabled = dest.CommonServiceItem.Settings.CommonServiceItemDestinationSettings.Disabled.Or(false)
+		status, err := destinationAPI.GetStatus(ctx, h.ID)
+		if err != nil {
+			return BulkResult{ID: h.ID, Err: err}
+		}
+		h.IsValid = status.NotificationStatus.IsValid
+		h.ModifiedAt = status.NotificationStatus.ModifiedAt
+		return BulkResult{ID: h.ID}
+	})
+	healthy := 0
+	for i, result := range results {
+		h := &d.Destinations[i]
+		h.Err = result.Err
+		if h.Healthy() {
+			healthy++
+			continue
+		}
+		d.Reasons = append(d.Reasons, unhealthyReason(*h))
+	}
+	switch {
+	case d.GroupDisabled:
+		d.Verdict = VerdictUnreachable
+		d.Reasons = append([]string{"group is disabled"}, d.Reasons...)
+	case len(d.Destinations) == 0:
+		d.Verdict = VerdictUnreachable
+		d.Reasons = append(d.Reasons, "group has no destinations")
+	case healthy == 0:
+		d.Verdict = VerdictUnreachable
+	case healthy < len(d.Destinations):
+		d.Verdict = VerdictDegraded
+	default:
+		d.Verdict = VerdictReachable
+	}
+	return d, bulkErr
+}
+
+func unhealthyReason(h DestinationHealth) string {
+	switch {
+	case saclient.IsNotFoundError(h.Err):
+		return fmt.Sprintf("destination %s is not found", h.ID)
+	case h.Err != nil:
+		return fmt.Sprintf("destination %s failed: %v", h.ID, h.Err)
+	case h.Disabled:
+		return fmt.Sprintf("destination %s (%s) is disabled", h.ID, h.Name)
+	}
+	return fmt.Sprintf("destination %s (%s) is not valid", h.ID, h.Name)
+}
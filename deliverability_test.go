@@ -0,0 +1,107 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeliverability(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	srv, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+	alice := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	bob := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("bob", "bob@example.com"))
+	})
+	disabled := fakeDestination("carol", "carol@example.com")
+	disabled.CommonServiceItem.Settings.CommonServiceItemDestinationSettings.Disabled = v1.NewOptBool(true)
+	carol := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, disabled)
+	})
+	group := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("ops", alice, bob))
+	})
+
+	d, err := simplenotification.CheckDeliverability(ctx, groupAPI, destinationAPI, group)
+	assert.NoError(err)
+	assert.Equal(simplenotification.VerdictReachable, d.Verdict)
+	assert.Equal("ops", d.GroupName)
+	assert.Empty(d.Reasons)
+	assert.Equal([]string{alice, bob}, []string{d.Destinations[0].ID, d.Destinations[1].ID})
+
+	srv.SetDestinationValid(bob, false)
+	d, err = simplenotification.CheckDeliverability(ctx, groupAPI, destinationAPI, group)
+	assert.NoError(err)
+	assert.Equal(simplenotification.VerdictDegraded, d.Verdict)
+	assert.Equal([]string{"destination " + bob + " (bob) is not valid"}, d.Reasons)
+
+	night := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("night", bob, carol))
+	})
+	d, err = simplenotification.CheckDeliverability(ctx, groupAPI, destinationAPI, night, simplenotification.WithConcurrency(1))
+	assert.NoError(err)
+	assert.Equal(simplenotification.VerdictUnreachable, d.Verdict)
+	assert.Equal([]string{
+		"destination " + bob + " (bob) is not valid",
+		"destination " + carol + " (carol) is disabled",
+	}, d.Reasons)
+
+	off := fakeGroup("off", alice)
+	off.CommonServiceItem.Settings.CommonServiceItemGroupSettings.Disabled = v1.NewOptBool(true)
+	offID := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, off)
+	})
+	d, err = simplenotification.CheckDeliverability(ctx, groupAPI, destinationAPI, offID)
+	assert.NoError(err)
+	assert.Equal(simplenotification.VerdictUnreachable, d.Verdict)
+	assert.Equal([]string{"group is disabled"}, d.Reasons)
+
+	_, err = simplenotification.CheckDeliverability(ctx, groupAPI, destinationAPI, "999999999999")
+	assert.True(saclient.IsNotFoundError(err))
+
+	// the id of a destination read as a group would have no destinations
+	_, err = simplenotification.CheckDeliverability(ctx, groupAPI, destinationAPI, alice)
+	assert.ErrorContains(err, "item "+alice+" is a saknoticedestination, not a group")
+
+	// a destination failing is reported in the reasons and in the error
+	failing := &simplenotificationmock.DestinationAPI{
+		ReadFunc: destinationAPI.Read,
+		GetStatusFunc: func(ctx context.Context, id string) (*v1.GetCommonServiceItemStatusResponse, error) {
+			if id == bob {
+				return nil, errors.New("unavailable")
+			}
+			return destinationAPI.GetStatus(ctx, id)
+		},
+	}
+	srv.SetDestinationValid(bob, true)
+	d, err = simplenotification.CheckDeliverability(ctx, groupAPI, failing, group)
+	var bulkErr *simplenotification.BulkError
+	assert.True(errors.As(err, &bulkErr))
+	assert.Equal(simplenotification.VerdictDegraded, d.Verdict)
+	assert.Equal([]string{"destination " + bob + " failed: unavailable"}, d.Reasons)
+}
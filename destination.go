@@ -43,8 +43,12 @@ func NewDestinationOp(client *v1.Client) DestinationAPI {
 func (o *DestinationOp) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
 	const methodName = "Destination.List"
 	ctx = setContextProviderClass(ctx, v1.CommonServiceItemProviderClassSaknoticedestination)
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.ListCommonServiceItems(ctx)
 	if err != nil {
+		if res, ok := lenientList(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -58,8 +62,12 @@ func (o *DestinationOp) Create(ctx context.Context, request v1.PostCommonService
 	const methodName = "Destination.Create"
 	request.CommonServiceItem.Provider.Class = v1.PostCommonServiceItemRequestCommonServiceItemProviderClassSaknoticedestination
 	request.CommonServiceItem.Settings.Type = v1.CommonServiceItemDestinationSettingsPostCommonServiceItemRequestCommonServiceItemSettings
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.CreateCommonServiceItem(ctx, v1.OptPostCommonServiceItemRequest{Value: request, Set: true})
 	if err != nil {
+		if res, ok := lenientCreate(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -71,8 +79,12 @@ func (o *DestinationOp) Create(ctx context.Context, request v1.PostCommonService
 
 func (o *DestinationOp) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
 	const methodName = "Destination.Read"
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.GetCommonServiceItem(ctx, v1.GetCommonServiceItemParams{ID: id})
 	if err != nil {
+		if res, ok := lenientRead(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -85,8 +97,12 @@ func (o *DestinationOp) Read(ctx context.Context, id string) (*v1.GetCommonServi
 func (o *DestinationOp) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
 	const methodName = "Destination.Update"
 	request.CommonServiceItem.Settings.Value.Type = v1.CommonServiceItemDestinationSettingsPutCommonServiceItemRequestCommonServiceItemSettings
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.UpdateCommonServiceItem(ctx, v1.OptPutCommonServiceItemRequest{Value: request, Set: true}, v1.UpdateCommonServiceItemParams{ID: id})
 	if err != nil {
+		if res, ok := lenientUpdate(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
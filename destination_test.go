@@ -19,8 +19,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/sacloud/packages-go/testutil"
-	"github.com/sacloud/saclient-go"
 	simplenotification "github.com/sacloud/simple-notification-api-go"
 	"github.com/stretchr/testify/require"
 
@@ -28,21 +26,13 @@ import (
 )
 
 func destinationAPISetup(t *testing.T) (ctx context.Context, api simplenotification.DestinationAPI) {
-
 	ctx = t.Context()
-	var saClient saclient.Client
-
-	client, err := simplenotification.NewClient(&saClient)
-	if err != nil {
-		t.Fatalf("failed to create client: %v", err)
-	}
-	api = simplenotification.NewDestinationOp(client)
+	api = simplenotification.NewDestinationOp(newTestClient(t, "SAKURA_DESTINATION_TEST_MAILADDRESS"))
 
 	return ctx, api
 }
 
 func TestDestinationOp(t *testing.T) {
-	testutil.PreCheckEnvsFunc("SAKURA_ACCESS_TOKEN", "SAKURA_ACCESS_TOKEN_SECRET", "SAKURA_DESTINATION_TEST_MAILADDRESS")(t)
 	ctx, destinationAPI := destinationAPISetup(t)
 	id := ""
 	destName := "test-destination-1"
@@ -0,0 +1,150 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"golang.org/x/sync/errgroup"
+)
+
+// ResourceRef is a resource referred to by a notification history, with its name when it still exists
+type ResourceRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Deleted is set when the ID is not found anymore, for a resource deleted since the notification
+	Deleted bool `json:"deleted"`
+}
+
+// DestinationRef is a destination referred to by a notification history
+type DestinationRef struct {
+	ResourceRef
+	Type v1.CommonServiceItemDestinationSettingsType `json:"type"`
+	// Value is the email address or the webhook URL redacted by RedactDestinationValue
+	Value string `json:"value"`
+}
+
+// EnrichedStatus is a delivery with its group and destination
+type EnrichedStatus struct {
+	Status      v1.NotificationStatus `json:"status"`
+	Group       ResourceRef           `json:"group"`
+	Destination DestinationRef        `json:"destination"`
+}
+
+// EnrichedHistory is a notification history with its source and the groups and destinations of its deliveries
+type EnrichedHistory struct {
+	History    v1.NotificationHistory `json:"history"`
+	Source     ResourceRef            `json:"source"`
+	Deliveries []EnrichedStatus       `json:"deliveries"`
+}
+
+// Enricher resolves the IDs of notification histories to the resources listed when it was created
+type Enricher struct {
+	inventory *Inventory
+	sources   map[string]string
+}
+
+// NewEnricher creates an Enricher from listed resources and sources
+func NewEnricher(inv *Inventory, sources []v1.ListSourcesResponseSourcesItem) *Enricher {
+	e := &Enricher{inventory: inv, sources: make(map[string]string, len(sources))}
+	for _, source := range sources {
+		e.sources[source.ID] = source.Name
+	}
+	return e
+}
+
+// LoadEnricher lists the resources and the sources in parallel and creates an Enricher from them
+func LoadEnricher(ctx context.Context, inventoryAPI InventoryAPI, routingAPI RoutingAPI) (*Enricher, error) {
+	var inv *Inventory
+	var sources *v1.ListSourcesResponse
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() (err error) {
+		inv, err = inventoryAPI.Inventory(ctx)
+		return err
+	})
+	eg.Go(func() (err error) {
+		sources, err = routingAPI.ListSource(ctx)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return NewEnricher(inv, sources.Sources), nil
+}
+
+// Enrich resolves the source, groups and destinations of a history.
+// The resources not found are marked Deleted instead of failing.
+func (e *Enricher) Enrich(history v1.NotificationHistory) EnrichedHistory {
+	enriched := EnrichedHistory{
+		History:    history,
+		Source:     ResourceRef{ID: history.SourceID},
+		Deliveries: make([]EnrichedStatus, len(history.Statuses)),
+	}
+	if name, ok := e.sources[history.SourceID]; ok {
+		enriched.Source.Name = name
+	} else {
+		enriched.Source.Deleted = history.SourceID != ""
+	}
+	for i, status := range history.Statuses {
+		delivery := EnrichedStatus{
+			Status:      status,
+			Group:       e.ref(status.GroupID, e.inventory.Group),
+			Destination: DestinationRef{ResourceRef: e.ref(status.DestinationID, e.inventory.Destination)},
+		}
+		if dest, ok := e.inventory.Destination(status.DestinationID); ok {
+			settings := dest.Settings.CommonServiceItemDestinationSettings
+			delivery.Destination.Type = settings.Type
+			delivery.Destination.Value = RedactDestinationValue(settings.Type, settings.Value)
+		}
+		enriched.Deliveries[i] = delivery
+	}
+	return enriched
+}
+
+func (e *Enricher) ref(id string, lookup func(id string) (v1.CommonServiceItem, bool)) ResourceRef {
+	if id == "" {
+		return ResourceRef{}
+	}
+	item, ok := lookup(id)
+	return ResourceRef{ID: id, Name: item.Name, Deleted: !ok}
+}
+
+// RedactDestinationValue hides most of a destination value while keeping it recognizable: the local part
+// of the address matched by EmailPattern but its first character, and the path and query of the URL matched by URLPattern.
+func RedactDestinationValue(typ v1.CommonServiceItemDestinationSettingsType, value string) string {
+	if value == "" {
+		return ""
+	}
+	switch typ {
+	case v1.CommonServiceItemDestinationSettingsTypeEmail:
+		address := EmailPattern.FindString(value)
+		if address == "" {
+			return "***"
+		}
+		_, size := utf8.DecodeRuneInString(address)
+		return address[:size] + "***" + address[strings.LastIndex(address, "@"):]
+	case v1.CommonServiceItemDestinationSettingsTypeWebhook:
+		u, err := url.Parse(URLPattern.FindString(value))
+		if err != nil || u.Host == "" {
+			return "***"
+		}
+		return u.Scheme + "://" + u.Host + "/***"
+	}
+	return "***"
+}
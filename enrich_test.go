@@ -0,0 +1,97 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"testing"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEnricher(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+	alice := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	webhook := fakeDestination("chat", "")
+	webhook.CommonServiceItem.Settings.CommonServiceItemDestinationSettings = v1.CommonServiceItemDestinationSettings{
+		Type:  v1.CommonServiceItemDestinationSettingsTypeWebhook,
+		Value: "https://hooks.example.com/services/T000/B000/secret",
+	}
+	chat := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, webhook)
+	})
+	group := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("ops", alice, chat))
+	})
+	_, err := groupAPI.SendMessage(ctx, group, v1.SendNotificationMessageRequest{Message: "hello"})
+	assert.NoError(err)
+	assert.NoError(groupAPI.Delete(ctx, group))
+	assert.NoError(destinationAPI.Delete(ctx, chat))
+
+	enricher, err := simplenotification.LoadEnricher(ctx, simplenotification.NewInventoryOp(client), simplenotification.NewRoutingOp(client))
+	assert.NoError(err)
+	histories, err := simplenotification.NewHistoryOp(client).List(ctx)
+	assert.NoError(err)
+	enriched := enricher.Enrich(histories.NotificationHistories[0])
+
+	assert.Equal("hello", enriched.History.Message.Body)
+	assert.Len(enriched.Deliveries, 2)
+	for _, delivery := range enriched.Deliveries {
+		assert.Equal(simplenotification.ResourceRef{ID: group, Deleted: true}, delivery.Group)
+	}
+	assert.Equal(simplenotification.DestinationRef{
+		ResourceRef: simplenotification.ResourceRef{ID: alice, Name: "alice"},
+		Type:        v1.CommonServiceItemDestinationSettingsTypeEmail,
+		Value:       "a***@example.com",
+	}, enriched.Deliveries[0].Destination)
+	assert.Equal(simplenotification.DestinationRef{
+		ResourceRef: simplenotification.ResourceRef{ID: chat, Deleted: true},
+	}, enriched.Deliveries[1].Destination)
+}
+
+func TestEnricher_Source(t *testing.T) {
+	assert := require.New(t)
+	enricher := simplenotification.NewEnricher(simplenotification.NewInventory(nil, nil, nil),
+		[]v1.ListSourcesResponseSourcesItem{{ID: "1", Name: "SimpleMonitor"}})
+
+	assert.Equal(simplenotification.ResourceRef{ID: "1", Name: "SimpleMonitor"}, enricher.Enrich(v1.NotificationHistory{SourceID: "1"}).Source)
+	assert.Equal(simplenotification.ResourceRef{ID: "9", Deleted: true}, enricher.Enrich(v1.NotificationHistory{SourceID: "9"}).Source)
+	assert.Equal(simplenotification.ResourceRef{}, enricher.Enrich(v1.NotificationHistory{}).Source)
+}
+
+func TestRedactDestinationValue(t *testing.T) {
+	for _, tt := range []struct {
+		typ   v1.CommonServiceItemDestinationSettingsType
+		value string
+		want  string
+	}{
+		{v1.CommonServiceItemDestinationSettingsTypeEmail, "alice@example.com", "a***@example.com"},
+		{v1.CommonServiceItemDestinationSettingsTypeEmail, "あいう@example.jp", "あ***@example.jp"},
+		{v1.CommonServiceItemDestinationSettingsTypeEmail, "not-an-address", "***"},
+		{v1.CommonServiceItemDestinationSettingsTypeWebhook, "https://hooks.example.com/services/T000?token=secret", "https://hooks.example.com/***"},
+		{v1.CommonServiceItemDestinationSettingsTypeWebhook, "not a url", "***"},
+		{"sms", "09012345678", "***"},
+		{v1.CommonServiceItemDestinationSettingsTypeEmail, "", ""},
+	} {
+		require.Equal(t, tt.want, simplenotification.RedactDestinationValue(tt.typ, tt.value), tt.value)
+	}
+}
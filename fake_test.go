@@ -0,0 +1,103 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"testing"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+)
+
+// fakeSetup returns a client talking to a fresh fake server
+func fakeSetup(t *testing.T, opts ...simplenotificationtest.Option) (*simplenotificationtest.Server, *v1.Client) {
+	srv := simplenotificationtest.NewServer(opts...)
+	t.Cleanup(srv.Close)
+	return srv, fakeClient(t, srv)
+}
+
+// fakeClient returns a client talking to the fake server through the middlewares
+func fakeClient(t *testing.T, srv *simplenotificationtest.Server, middlewares ...saclient.Middleware) *v1.Client {
+	var saClient saclient.Client
+	if err := saClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+	if err := saClient.SetWith(saclient.WithMiddleware(middlewares...)); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+	client, err := simplenotification.NewClientWithAPIRootURL(&saClient, srv.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func fakeDestination(name, mailAddress string) v1.PostCommonServiceItemRequest {
+	return v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: name,
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemDestinationSettings: v1.CommonServiceItemDestinationSettings{
+					Type:  v1.CommonServiceItemDestinationSettingsTypeEmail,
+					Value: mailAddress,
+				},
+			},
+		},
+	}
+}
+
+func fakeGroup(name string, destinations ...string) v1.PostCommonServiceItemRequest {
+	return v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: name,
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemGroupSettings: v1.CommonServiceItemGroupSettings{
+					Destinations: destinations,
+				},
+			},
+		},
+	}
+}
+
+func fakeRouting(name, sourceID, groupID string) v1.PostCommonServiceItemRequest {
+	return v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: name,
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemRoutingSettings: v1.CommonServiceItemRoutingSettings{
+					MatchLabels:   []v1.CommonServiceItemRoutingSettingsMatchLabelsItem{},
+					SourceID:      sourceID,
+					TargetGroupID: groupID,
+					PriorityRank:  1,
+				},
+			},
+		},
+	}
+}
+
+// mustCreate creates a resource with the op or fails the test, returning its ID
+func mustCreate(t *testing.T, create func() (*v1.CreateCommonServiceItemCreated, error)) string {
+	t.Helper()
+	res, err := create()
+	if err != nil {
+		t.Fatalf("failed to create: %v", err)
+	}
+	return res.CommonServiceItem.ID
+}
@@ -45,8 +45,12 @@ func (o *GroupOp) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse,
 	const methodName = "Group.List"
 
 	ctx = setContextProviderClass(ctx, v1.CommonServiceItemProviderClassSaknoticegroup)
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.ListCommonServiceItems(ctx)
 	if err != nil {
+		if res, ok := lenientList(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -63,8 +67,12 @@ func (o *GroupOp) Create(ctx context.Context, request v1.PostCommonServiceItemRe
 	request.CommonServiceItem.Provider.ServiceClass = v1.OptString{Value: "cloud/saknotice", Set: true}
 	request.CommonServiceItem.Settings.Type = v1.CommonServiceItemGroupSettingsPostCommonServiceItemRequestCommonServiceItemSettings
 
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.CreateCommonServiceItem(ctx, v1.OptPostCommonServiceItemRequest{Value: request, Set: true})
 	if err != nil {
+		if res, ok := lenientCreate(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -76,8 +84,12 @@ func (o *GroupOp) Create(ctx context.Context, request v1.PostCommonServiceItemRe
 
 func (o *GroupOp) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
 	const methodName = "Group.Read"
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.GetCommonServiceItem(ctx, v1.GetCommonServiceItemParams{ID: id})
 	if err != nil {
+		if res, ok := lenientRead(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -90,8 +102,12 @@ func (o *GroupOp) Read(ctx context.Context, id string) (*v1.GetCommonServiceItem
 func (o *GroupOp) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
 	const methodName = "Group.Update"
 	request.CommonServiceItem.Settings.Value.Type = v1.CommonServiceItemGroupSettingsPutCommonServiceItemRequestCommonServiceItemSettings
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.UpdateCommonServiceItem(ctx, v1.OptPutCommonServiceItemRequest{Value: request, Set: true}, v1.UpdateCommonServiceItemParams{ID: id})
 	if err != nil {
+		if res, ok := lenientUpdate(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -19,8 +19,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/sacloud/packages-go/testutil"
-	"github.com/sacloud/saclient-go"
 	simplenotification "github.com/sacloud/simple-notification-api-go"
 	"github.com/stretchr/testify/require"
 
@@ -28,17 +26,8 @@ import (
 )
 
 func groupAPISetup(t *testing.T) (ctx context.Context, api simplenotification.GroupAPI) {
-	testutil.PreCheckEnvsFunc("SAKURA_ACCESS_TOKEN", "SAKURA_ACCESS_TOKEN_SECRET", "SAKURA_DESTINATION_TEST_ID")(t)
-
 	ctx = t.Context()
-
-	var saClient saclient.Client
-
-	client, err := simplenotification.NewClient(&saClient)
-	if err != nil {
-		t.Fatalf("failed to create client: %v", err)
-	}
-	api = simplenotification.NewGroupOp(client)
+	api = simplenotification.NewGroupOp(newTestClient(t, "SAKURA_DESTINATION_TEST_ID"))
 
 	return ctx, api
 }
@@ -0,0 +1,134 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// DestinationHealth is the status of a destination checked by CheckDestinations
+type DestinationHealth struct {
+	ID       string
+	Name     string
+	Type     v1.CommonServiceItemDestinationSettingsType
+	Disabled bool
+	// IsValid and ModifiedAt are the ones returned by GetStatus
+	IsValid    bool
+	ModifiedAt time.Time
+	// Err is the error of GetStatus, or of the context when the destination was not checked
+	Err error
+}
+
+// Healthy reports whether the destination can receive notifications: it is enabled, valid and was checked
+func (h DestinationHealth) Healthy() bool {
+	return h.Err == nil && h.IsValid && !h.Disabled
+}
+
+// HealthReport is the status of every destination, in the order of List
+type HealthReport struct {
+	Destinations []DestinationHealth
+}
+
+// Healthy reports whether every destination is healthy
+func (r *HealthReport) Healthy() bool {
+	return len(r.Unhealthy()) == 0
+}
+
+// Unhealthy returns the destinations not healthy
+func (r *HealthReport) Unhealthy() []DestinationHealth {
+	var unhealthy []DestinationHealth
+	for _, h := range r.Destinations {
+		if !h.Healthy() {
+			unhealthy = append(unhealthy, h)
+		}
+	}
+	return unhealthy
+}
+
+// DestinationNotValidError reports a destination still not valid when WaitUntilValid gave up
+type DestinationNotValidError struct {
+	ID     string
+	Checks int
+}
+
+func (e *DestinationNotValidError) Error() string {
+	return fmt.Sprintf("destination %s is not valid after %d checks", e.ID, e.Checks)
+}
+
+// CheckDestinations lists the destinations with api and gets their status in parallel, 4 at a time unless
+// WithConcurrency is given. A failed GetStatus is reported in the entry of its destination, and the report
+// comes with a *BulkError of the destinations that failed or were not checked. The report is nil when List fails.
+func CheckDestinations(ctx context.Context, api DestinationAPI, opts ...BulkOption) (*HealthReport, error) {
+	list, err := api.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &HealthReport{}
+	for _, item := range list.CommonServiceItems {
+		if IsUnknown(item) {
+			continue
+		}
+		settings := item.Settings.CommonServiceItemDestinationSettings
+		report.Destinations = append(report.Destinations, DestinationHealth{
+			ID:       item.ID,
+			Name:     item.Name,
+			Type:     settings.Type,
+			Disabled: settings.Disabled.Or(false),
+		})
+	}
+	results, err := runBulk(ctx, len(report.Destinations), opts, func(ctx context.Context, i int) BulkResult {
+		h := &report.Destinations[i]
+		res, err := api.GetStatus(ctx, h.ID)
+		if err != nil {
+			return BulkResult{ID: h.ID, Err: err}
+		}
+		h.IsValid = res.NotificationStatus.IsValid
+		h.ModifiedAt = res.NotificationStatus.ModifiedAt
+		return BulkResult{ID: h.ID}
+	})
+	for i, result := range results {
+		report.Destinations[i].Err = result.Err
+	}
+	return report, err
+}
+
+// WaitUntilValid gets the status of the destination with api until it is valid, waiting between the checks as the backoff
+// does between retries. MinBackoff and MaxBackoff of the backoff must be positive. MaxAttempts bounds the number of checks,
+// after which a *DestinationNotValidError is returned; less than 1 checks until the context is done.
+// An error of GetStatus, such as a destination not found, ends the wait.
+func WaitUntilValid(ctx context.Context, api DestinationAPI, id string, backoff RetryPolicy) error {
+	if backoff.MinBackoff <= 0 || backoff.MaxBackoff <= 0 {
+		return NewError("WaitUntilValid", fmt.Errorf("backoff must have a positive MinBackoff and MaxBackoff, got %s and %s", backoff.MinBackoff, backoff.MaxBackoff))
+	}
+	for check := 1; ; check++ {
+		res, err := api.GetStatus(ctx, id)
+		if err != nil {
+			return err
+		}
+		if res.NotificationStatus.IsValid {
+			return nil
+		}
+		if backoff.MaxAttempts > 0 && check >= backoff.MaxAttempts {
+			return &DestinationNotValidError{ID: id, Checks: check}
+		}
+		if err := sleepContext(ctx, backoff.backoff(check)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDestinations(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	srv, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	alice := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	bob := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("bob", "bob@example.invalid"))
+	})
+	disabled := fakeDestination("carol", "carol@example.com")
+	disabled.CommonServiceItem.Settings.CommonServiceItemDestinationSettings.Disabled = v1.NewOptBool(true)
+	carol := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, disabled)
+	})
+	srv.SetDestinationValid(bob, false)
+
+	report, err := simplenotification.CheckDestinations(ctx, destinationAPI, simplenotification.WithConcurrency(2))
+	assert.NoError(err)
+	assert.Len(report.Destinations, 3)
+	assert.False(report.Healthy())
+	health := map[string]simplenotification.DestinationHealth{}
+	for _, h := range report.Destinations {
+		assert.NoError(h.Err)
+		health[h.ID] = h
+	}
+	assert.True(health[alice].Healthy())
+	assert.Equal("alice", health[alice].Name)
+	assert.Equal(v1.CommonServiceItemDestinationSettingsTypeEmail, health[alice].Type)
+	assert.False(health[alice].ModifiedAt.IsZero())
+	assert.False(health[bob].IsValid)
+	assert.True(health[carol].IsValid)
+	assert.True(health[carol].Disabled)
+	unhealthy := report.Unhealthy()
+	assert.Len(unhealthy, 2)
+	assert.ElementsMatch([]string{bob, carol}, []string{unhealthy[0].ID, unhealthy[1].ID})
+
+	srv.SetDestinationValid(bob, true)
+	assert.NoError(destinationAPI.Delete(ctx, carol))
+	report, err = simplenotification.CheckDestinations(ctx, destinationAPI)
+	assert.NoError(err)
+	assert.True(report.Healthy())
+
+	// a destination deleted after the list is reported in its entry and in the error
+	dave := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("dave", "dave@example.com"))
+	})
+	deleting := &simplenotificationmock.DestinationAPI{
+		ListFunc: func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+			res, err := destinationAPI.List(ctx)
+			if err == nil {
+				err = destinationAPI.Delete(ctx, dave)
+			}
+			return res, err
+		},
+		GetStatusFunc: destinationAPI.GetStatus,
+	}
+	report, err = simplenotification.CheckDestinations(ctx, deleting)
+	var bulkErr *simplenotification.BulkError
+	assert.True(errors.As(err, &bulkErr))
+	assert.Len(report.Destinations, 3)
+	for _, h := range report.Destinations {
+		if h.ID == dave {
+			assert.True(saclient.IsNotFoundError(h.Err))
+		} else {
+			assert.NoError(h.Err)
+		}
+	}
+}
+
+func TestWaitUntilValid(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	srv, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	id := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	backoff := simplenotification.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	// a backoff without waits would get the status in a tight loop
+	for _, invalid := range []simplenotification.RetryPolicy{{}, {MaxAttempts: 3}, {MinBackoff: time.Millisecond}, {MinBackoff: -time.Second, MaxBackoff: time.Second}} {
+		assert.ErrorContains(simplenotification.WaitUntilValid(ctx, destinationAPI, id, invalid), "positive MinBackoff and MaxBackoff")
+	}
+
+	assert.NoError(simplenotification.WaitUntilValid(ctx, destinationAPI, id, backoff))
+
+	srv.SetDestinationValid(id, false)
+	err := simplenotification.WaitUntilValid(ctx, destinationAPI, id, backoff)
+	var notValid *simplenotification.DestinationNotValidError
+	assert.True(errors.As(err, &notValid))
+	assert.Equal(&simplenotification.DestinationNotValidError{ID: id, Checks: 3}, notValid)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		srv.SetDestinationValid(id, true)
+	}()
+	assert.NoError(simplenotification.WaitUntilValid(ctx, destinationAPI, id, simplenotification.RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}))
+
+	err = simplenotification.WaitUntilValid(ctx, destinationAPI, "999999999999", backoff)
+	assert.True(saclient.IsNotFoundError(err))
+
+	srv.SetDestinationValid(id, false)
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	err = simplenotification.WaitUntilValid(ctx, destinationAPI, id, simplenotification.RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
@@ -18,23 +18,12 @@ import (
 	"context"
 	"testing"
 
-	"github.com/sacloud/packages-go/testutil"
-	"github.com/sacloud/saclient-go"
 	simplenotification "github.com/sacloud/simple-notification-api-go"
 )
 
 func historyAPISetup(t *testing.T) (ctx context.Context, api simplenotification.HistoryAPI) {
-	testutil.PreCheckEnvsFunc("SAKURA_ACCESS_TOKEN", "SAKURA_ACCESS_TOKEN_SECRET")(t)
-
 	ctx = t.Context()
-
-	var saClient saclient.Client
-
-	client, err := simplenotification.NewClient(&saClient)
-	if err != nil {
-		t.Fatalf("failed to create client: %v", err)
-	}
-	api = simplenotification.NewHistoryOp(client)
+	api = simplenotification.NewHistoryOp(newTestClient(t))
 
 	return ctx, api
 }
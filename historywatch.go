@@ -0,0 +1,176 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// HistoryEventType is the kind of event reported by WatchHistory
+type HistoryEventType string
+
+const (
+	// HistoryReceived reports a new notification history
+	HistoryReceived HistoryEventType = "received"
+	// HistoryFinalized reports a delivery whose status became 2 (sent) or 9 (failed)
+	HistoryFinalized HistoryEventType = "finalized"
+)
+
+// HistoryEvent is a new notification history or a finalized delivery found by WatchHistory
+type HistoryEvent struct {
+	Type    HistoryEventType
+	History v1.NotificationHistory
+	// Status is the finalized delivery, with its ErrorInfo when it failed. It is nil for HistoryReceived.
+	Status *v1.NotificationStatus
+}
+
+// Failed reports whether the event is a failed delivery
+func (e *HistoryEvent) Failed() bool {
+	return e.Type == HistoryFinalized && e.Status.Status == v1.NotificationStatusStatus9
+}
+
+// HistoryWatchOption configures WatchHistory
+type HistoryWatchOption func(*historyWatchConfig)
+
+type historyWatchConfig struct {
+	since time.Time
+}
+
+// WithHistorySince makes the first poll report the histories received at or after t, such as the ReceivedAt
+// of the last event handled before a restart. Without it the histories of the first poll are not reported,
+// only the deliveries finalized after it.
+func WithHistorySince(t time.Time) HistoryWatchOption {
+	return func(c *historyWatchConfig) { c.since = t }
+}
+
+// WatchHistory polls the notification histories with api every interval and yields the new ones and the deliveries
+// finalized since the last poll, the oldest first. Each history and each final status is reported once,
+// also after it leaves the window of the latest histories returned by List. A failed poll yields its error
+// and the watch goes on until the context is done or the loop breaks.
+// An interval of zero or less yields an error and ends the watch.
+func WatchHistory(ctx context.Context, api HistoryAPI, interval time.Duration, opts ...HistoryWatchOption) iter.Seq2[*HistoryEvent, error] {
+	var config historyWatchConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return func(yield func(*HistoryEvent, error) bool) {
+		if interval <= 0 {
+			yield(nil, NewError("WatchHistory", fmt.Errorf("interval must be positive, got %s", interval)))
+			return
+		}
+		state := historyWatchState{seen: map[string]*seenHistory{}}
+		first := true
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			res, err := api.List(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				if !yield(nil, err) {
+					return
+				}
+			default:
+				for event := range state.poll(res.NotificationHistories, first, config.since) {
+					if !yield(event, nil) {
+						return
+					}
+				}
+				first = false
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// historyWatchState remembers the histories and final statuses reported.
+// The histories received before horizon left the window of List and are forgotten, and ignored if they come back.
+type historyWatchState struct {
+	seen    map[string]*seenHistory
+	horizon time.Time
+}
+
+type seenHistory struct {
+	receivedAt time.Time
+	// final are the final statuses reported, by status ID and status
+	final map[finalStatus]bool
+}
+
+type finalStatus struct {
+	id     string
+	status v1.NotificationStatusStatus
+}
+
+func (s *historyWatchState) poll(histories []v1.NotificationHistory, first bool, since time.Time) iter.Seq[*HistoryEvent] {
+	histories = slices.Clone(histories)
+	slices.SortStableFunc(histories, func(a, b v1.NotificationHistory) int { return a.ReceivedAt.Compare(b.ReceivedAt) })
+	return func(yield func(*HistoryEvent) bool) {
+		defer s.prune(histories)
+		for _, history := range histories {
+			seen, ok := s.seen[history.RequestID]
+			if !ok {
+				if history.ReceivedAt.Before(s.horizon) {
+					continue
+				}
+				seen = &seenHistory{receivedAt: history.ReceivedAt, final: map[finalStatus]bool{}}
+				s.seen[history.RequestID] = seen
+			}
+			// the histories of the first poll are the baseline, unless received since the given time
+			report := !first || (!since.IsZero() && !history.ReceivedAt.Before(since))
+			if !ok && report && !yield(&HistoryEvent{Type: HistoryReceived, History: history}) {
+				return
+			}
+			for i, status := range history.Statuses {
+				if status.Status != v1.NotificationStatusStatus2 && status.Status != v1.NotificationStatusStatus9 {
+					continue
+				}
+				key := finalStatus{id: status.ID, status: status.Status}
+				if seen.final[key] {
+					continue
+				}
+				seen.final[key] = true
+				if report && !yield(&HistoryEvent{Type: HistoryFinalized, History: history, Status: &history.Statuses[i]}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// prune forgets the histories received before the oldest one listed, which left the window
+func (s *historyWatchState) prune(histories []v1.NotificationHistory) {
+	if len(histories) == 0 {
+		return
+	}
+	if histories[0].ReceivedAt.After(s.horizon) {
+		s.horizon = histories[0].ReceivedAt
+	}
+	for id, seen := range s.seen {
+		if seen.receivedAt.Before(s.horizon) {
+			delete(s.seen, id)
+		}
+	}
+}
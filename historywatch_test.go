@@ -0,0 +1,131 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"context"
+	"iter"
+	"testing"
+	"time"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationmock"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchHistory(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	srv, client := fakeSetup(t, simplenotificationtest.WithClock(func() time.Time { return now }))
+	groupAPI := simplenotification.NewGroupOp(client)
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	group := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("ops", dest))
+	})
+	_, err := groupAPI.SendMessage(ctx, group, v1.SendNotificationMessageRequest{Message: "before"})
+	assert.NoError(err)
+	now = now.Add(time.Minute)
+	_, err = groupAPI.SendMessage(ctx, group, v1.SendNotificationMessageRequest{Message: "after"})
+	assert.NoError(err)
+
+	next, stop := iter.Pull2(simplenotification.WatchHistory(ctx, simplenotification.NewHistoryOp(client), time.Millisecond, simplenotification.WithHistorySince(now)))
+	defer stop()
+	event := func() *simplenotification.HistoryEvent {
+		t.Helper()
+		event, err, ok := next()
+		assert.True(ok)
+		assert.NoError(err)
+		return event
+	}
+
+	received := event()
+	assert.Equal(simplenotification.HistoryReceived, received.Type)
+	assert.Equal("after", received.History.Message.Body)
+	sent := event()
+	assert.Equal(simplenotification.HistoryFinalized, sent.Type)
+	assert.Equal(received.History.RequestID, sent.History.RequestID)
+	assert.Equal(v1.NotificationStatusStatus2, sent.Status.Status)
+	assert.False(sent.Failed())
+
+	histories, err := simplenotification.NewHistoryOp(client).List(ctx)
+	assert.NoError(err)
+	before := histories.NotificationHistories[1]
+	assert.Equal("before", before.Message.Body)
+	assert.NoError(srv.SetDeliveryStatus(before.RequestID, dest, v1.NotificationStatusStatus9, "mailbox full"))
+	failed := event()
+	assert.Equal(simplenotification.HistoryFinalized, failed.Type)
+	assert.True(failed.Failed())
+	assert.Equal(before.RequestID, failed.History.RequestID)
+	assert.Equal("mailbox full", failed.Status.ErrorInfo)
+}
+
+func TestWatchHistory_Window(t *testing.T) {
+	assert := require.New(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	history := func(id string, minute int, status v1.NotificationStatusStatus) v1.NotificationHistory {
+		return v1.NotificationHistory{
+			RequestID:  id,
+			ReceivedAt: time.Date(2026, 4, 1, 9, minute, 0, 0, time.UTC),
+			Statuses:   []v1.NotificationStatus{{ID: id + "-1", Status: status}},
+		}
+	}
+	a, b, c := history("a", 0, v1.NotificationStatusStatus2), history("b", 1, v1.NotificationStatusStatus1), history("c", 2, v1.NotificationStatusStatus1)
+	bSent, cFailed := history("b", 1, v1.NotificationStatusStatus2), history("c", 2, v1.NotificationStatusStatus9)
+	// a window of two histories, newest first, where a comes back after leaving it
+	polls := [][]v1.NotificationHistory{
+		{b, a},
+		{c, bSent},
+		{cFailed, a},
+		{cFailed, bSent},
+		{cFailed, a},
+	}
+	n := 0
+	api := &simplenotificationmock.HistoryAPI{
+		ListFunc: func(ctx context.Context) (*v1.ListSimpleNotificationHistoriesResponse, error) {
+			if n == len(polls) {
+				cancel()
+				return nil, ctx.Err()
+			}
+			histories := polls[n]
+			n++
+			return &v1.ListSimpleNotificationHistoriesResponse{NotificationHistories: histories}, nil
+		},
+	}
+
+	var events []string
+	for event, err := range simplenotification.WatchHistory(ctx, api, time.Millisecond) {
+		assert.NoError(err)
+		events = append(events, event.History.RequestID+" "+string(event.Type))
+	}
+	assert.Equal([]string{"b finalized", "c received", "c finalized"}, events)
+}
+
+func TestWatchHistory_Interval(t *testing.T) {
+	assert := require.New(t)
+	_, client := fakeSetup(t)
+	var errs []error
+	for event, err := range simplenotification.WatchHistory(t.Context(), simplenotification.NewHistoryOp(client), -time.Second) {
+		assert.Nil(event)
+		errs = append(errs, err)
+	}
+	assert.Len(errs, 1)
+	assert.ErrorContains(errs[0], "WatchHistory: interval must be positive, got -1s")
+}
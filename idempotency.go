@@ -0,0 +1,405 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// historyClockSkew is the tolerance when comparing the time of an attempt with the time a history was received
+const historyClockSkew = time.Minute
+
+// inFlightTimeout is how long an attempt is taken to be still in flight, after which its sender is assumed
+// to have stopped without recording the outcome
+const inFlightTimeout = 5 * time.Minute
+
+// SendOption configures SendMessage
+type SendOption func(*sendConfig)
+
+type sendConfig struct {
+	key     string
+	store   IdempotencyStore
+	history HistoryAPI
+}
+
+func newSendConfig(opts []SendOption) sendConfig {
+	var config sendConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// WithIdempotencyKey makes SendMessage send the message of key only once within the window of store.
+//
+// A message already sent with key, or being sent by another caller, is not sent again and SendMessage returns
+// a *DuplicateMessageError. When it is unknown whether an attempt reached the server, such as on a timeout
+// or a 5xx response, the message is looked for in history before it is sent again.
+func WithIdempotencyKey(key string, store IdempotencyStore, history HistoryAPI) SendOption {
+	return func(c *sendConfig) {
+		c.key = key
+		c.store = store
+		c.history = history
+	}
+}
+
+// IdempotencyRecord is what an IdempotencyStore remembers of the message sent with a key
+type IdempotencyRecord struct {
+	// AttemptedAt is when the message was last sent
+	AttemptedAt time.Time `json:"attempted_at"`
+	// Confirmed tells that the message was accepted. Otherwise the outcome of the attempt is unknown.
+	Confirmed bool `json:"confirmed"`
+	// InFlight tells that the attempt has not completed yet
+	InFlight bool `json:"in_flight,omitempty"`
+	// RequestID is the request ID of the history of the message, when it was found there
+	RequestID string `json:"request_id,omitempty"`
+}
+
+func (r IdempotencyRecord) equal(other IdempotencyRecord) bool {
+	return r.AttemptedAt.Equal(other.AttemptedAt) && r.Confirmed == other.Confirmed && r.InFlight == other.InFlight && r.RequestID == other.RequestID
+}
+
+// IdempotencyStore remembers idempotency keys for a time window, after which Load does not find them.
+//
+// Claim and CompareAndSwap must be atomic, including between processes sharing the store,
+// so that of the callers sending a message with the same key only one sends it.
+type IdempotencyStore interface {
+	Load(ctx context.Context, key string) (IdempotencyRecord, bool, error)
+	Save(ctx context.Context, key string, record IdempotencyRecord) error
+	Delete(ctx context.Context, key string) error
+	// Claim saves record unless key has one, which is returned with true
+	Claim(ctx context.Context, key string, record IdempotencyRecord) (IdempotencyRecord, bool, error)
+	// CompareAndSwap saves record if key has the record old, reporting whether it did
+	CompareAndSwap(ctx context.Context, key string, old, record IdempotencyRecord) (bool, error)
+}
+
+// DuplicateMessageError reports a message not sent because it was already sent with the same idempotency key
+type DuplicateMessageError struct {
+	Key       string
+	SentAt    time.Time
+	RequestID string
+	// InFlight tells that the message is being sent by another caller, whose outcome is not known yet
+	InFlight bool
+}
+
+func (e *DuplicateMessageError) Error() string {
+	if e.InFlight {
+		return fmt.Sprintf("duplicate message suppressed: idempotency key %q is being sent since %s", e.Key, e.SentAt.Format(time.RFC3339))
+	}
+	msg := fmt.Sprintf("duplicate message suppressed: idempotency key %q was sent at %s", e.Key, e.SentAt.Format(time.RFC3339))
+	if e.RequestID != "" {
+		msg += " as request " + e.RequestID
+	}
+	return msg
+}
+
+// SendMessage sends the message to the group with api following the options.
+// Without WithIdempotencyKey it is the same as api.SendMessage.
+func SendMessage(ctx context.Context, api GroupAPI, id string, request v1.SendNotificationMessageRequest, opts ...SendOption) (*v1.SendNotificationMessageResponse, error) {
+	config := newSendConfig(opts)
+	if config.store == nil {
+		return api.SendMessage(ctx, id, request)
+	}
+	return sendIdempotently(ctx, api, id, request, config)
+}
+
+// sendIdempotently sends a message unless the store or the history tells that it was already sent
+func sendIdempotently(ctx context.Context, api GroupAPI, id string, request v1.SendNotificationMessageRequest, config sendConfig) (*v1.SendNotificationMessageResponse, error) {
+	store, key := config.store, config.key
+	attempt := IdempotencyRecord{AttemptedAt: time.Now(), InFlight: true}
+	for {
+		record, claimed, err := store.Claim(ctx, key, attempt)
+		if err != nil {
+			return nil, err
+		}
+		if !claimed {
+			break
+		}
+		if record.Confirmed {
+			return nil, &DuplicateMessageError{Key: key, SentAt: record.AttemptedAt, RequestID: record.RequestID}
+		}
+		if record.InFlight && time.Since(record.AttemptedAt) < inFlightTimeout {
+			return nil, &DuplicateMessageError{Key: key, SentAt: record.AttemptedAt, InFlight: true}
+		}
+		history, err := findSent(ctx, config.history, id, request, record.AttemptedAt)
+		if err != nil {
+			return nil, fmt.Errorf("checking the history of idempotency key %q: %w", key, err)
+		}
+		if history != nil {
+			confirmed := IdempotencyRecord{AttemptedAt: history.ReceivedAt, Confirmed: true, RequestID: history.RequestID}
+			if _, err := store.CompareAndSwap(ctx, key, record, confirmed); err != nil {
+				return nil, err
+			}
+			return nil, &DuplicateMessageError{Key: key, SentAt: history.ReceivedAt, RequestID: history.RequestID}
+		}
+		// the attempt did not reach the server: take it over unless another caller did
+		swapped, err := store.CompareAndSwap(ctx, key, record, attempt)
+		if err != nil {
+			return nil, err
+		}
+		if swapped {
+			break
+		}
+	}
+
+	res, err := api.SendMessage(ctx, id, request)
+	outcome := IdempotencyRecord{AttemptedAt: attempt.AttemptedAt}
+	switch {
+	case err == nil:
+		outcome.Confirmed = true
+	case !isAmbiguous(err):
+		return nil, errors.Join(err, store.Delete(context.WithoutCancel(ctx), key))
+	case ctx.Err() != nil:
+		// the attempt stays unconfirmed, to be checked by the next call
+	default:
+		history, herr := findSent(ctx, config.history, id, request, attempt.AttemptedAt)
+		if herr == nil && history != nil {
+			outcome = IdempotencyRecord{AttemptedAt: history.ReceivedAt, Confirmed: true, RequestID: history.RequestID}
+			res, err = &v1.SendNotificationMessageResponse{IsOk: true}, nil
+		}
+	}
+	if serr := store.Save(context.WithoutCancel(ctx), key, outcome); serr != nil {
+		return nil, errors.Join(err, serr)
+	}
+	return res, err
+}
+
+// findSent looks in the history for the message sent to the group since the attempt.
+// A history matches when it has the message and a status of its request for the group.
+func findSent(ctx context.Context, api HistoryAPI, id string, request v1.SendNotificationMessageRequest, attemptedAt time.Time) (*v1.NotificationHistory, error) {
+	if api == nil {
+		return nil, nil
+	}
+	res, err := api.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range res.NotificationHistories {
+		if h.Message.Body != request.Message || h.ReceivedAt.Before(attemptedAt.Add(-historyClockSkew)) {
+			continue
+		}
+		if slices.ContainsFunc(h.Statuses, func(s v1.NotificationStatus) bool {
+			return s.GroupID == id && s.NotificationRequestID == h.RequestID
+		}) {
+			return &h, nil
+		}
+	}
+	return nil, nil
+}
+
+// isAmbiguous reports whether a failed request may have been accepted by the server
+func isAmbiguous(err error) bool {
+	var e *v1.ErrorStatusCode
+	if errors.As(err, &e) {
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	return isConnectionError(err) && !isUnreachable(err)
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore in memory, for a single process
+type MemoryIdempotencyStore struct {
+	window  time.Duration
+	mu      sync.Mutex
+	records map[string]IdempotencyRecord
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+// NewMemoryIdempotencyStore creates a store remembering keys for window
+func NewMemoryIdempotencyStore(window time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{window: window, records: map[string]IdempotencyRecord{}}
+}
+
+func (s *MemoryIdempotencyStore) Load(_ context.Context, key string) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expire(s.records, s.window)
+	record, ok := s.records[key]
+	return record, ok, nil
+}
+
+func (s *MemoryIdempotencyStore) Save(_ context.Context, key string, record IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = record
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func (s *MemoryIdempotencyStore) Claim(_ context.Context, key string, record IdempotencyRecord) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expire(s.records, s.window)
+	if existing, ok := s.records[key]; ok {
+		return existing, true, nil
+	}
+	s.records[key] = record
+	return record, false, nil
+}
+
+func (s *MemoryIdempotencyStore) CompareAndSwap(_ context.Context, key string, old, record IdempotencyRecord) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expire(s.records, s.window)
+	if existing, ok := s.records[key]; !ok || !existing.equal(old) {
+		return false, nil
+	}
+	s.records[key] = record
+	return true, nil
+}
+
+// FileIdempotencyStore is an IdempotencyStore in a JSON file, surviving restarts of the process.
+// The file is rewritten on every change, so it is meant for the modest rate of alerts.
+// Claim and CompareAndSwap are atomic within the process only: processes must not share the file.
+type FileIdempotencyStore struct {
+	path   string
+	window time.Duration
+	mu     sync.Mutex
+}
+
+var _ IdempotencyStore = (*FileIdempotencyStore)(nil)
+
+// NewFileIdempotencyStore creates a store remembering keys for window in the file at path, created when missing
+func NewFileIdempotencyStore(path string, window time.Duration) *FileIdempotencyStore {
+	return &FileIdempotencyStore{path: path, window: window}
+}
+
+func (s *FileIdempotencyStore) Load(_ context.Context, key string) (IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, err := s.read()
+	if err != nil {
+		return IdempotencyRecord{}, false, err
+	}
+	record, ok := records[key]
+	return record, ok, nil
+}
+
+func (s *FileIdempotencyStore) Save(_ context.Context, key string, record IdempotencyRecord) error {
+	return s.update(func(records map[string]IdempotencyRecord) bool {
+		records[key] = record
+		return true
+	})
+}
+
+func (s *FileIdempotencyStore) Delete(_ context.Context, key string) error {
+	return s.update(func(records map[string]IdempotencyRecord) bool {
+		delete(records, key)
+		return true
+	})
+}
+
+func (s *FileIdempotencyStore) Claim(_ context.Context, key string, record IdempotencyRecord) (IdempotencyRecord, bool, error) {
+	existing, claimed := record, false
+	err := s.update(func(records map[string]IdempotencyRecord) bool {
+		if r, ok := records[key]; ok {
+			existing, claimed = r, true
+			return false
+		}
+		records[key] = record
+		return true
+	})
+	return existing, claimed, err
+}
+
+func (s *FileIdempotencyStore) CompareAndSwap(_ context.Context, key string, old, record IdempotencyRecord) (bool, error) {
+	swapped := false
+	err := s.update(func(records map[string]IdempotencyRecord) bool {
+		if r, ok := records[key]; !ok || !r.equal(old) {
+			return false
+		}
+		records[key] = record
+		swapped = true
+		return true
+	})
+	return swapped, err
+}
+
+// update applies f to the records, writing them back when it reports a change
+func (s *FileIdempotencyStore) update(f func(map[string]IdempotencyRecord) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records, err := s.read()
+	if err != nil {
+		return err
+	}
+	if !f(records) {
+		return nil
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileIdempotencyStore) read() (map[string]IdempotencyRecord, error) {
+	records := map[string]IdempotencyRecord{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("reading idempotency keys from %s: %w", s.path, err)
+	}
+	expire(records, s.window)
+	return records, nil
+}
+
+// expire drops the records attempted before the window
+func expire(records map[string]IdempotencyRecord, window time.Duration) {
+	limit := time.Now().Add(-window)
+	for key, record := range records {
+		if record.AttemptedAt.Before(limit) {
+			delete(records, key)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+	"github.com/stretchr/testify/require"
+)
+
+// lostResponseClient returns a client whose message requests reach the server but fail with 503 while lost is set
+func lostResponseClient(t *testing.T, srv *simplenotificationtest.Server, lost *bool) *v1.Client {
+	return fakeClient(t, srv, func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		cont, ok := pull()
+		if !ok {
+			return nil, errors.New("middleware not found error")
+		}
+		resp, err := cont(req, pull)
+		if err != nil || !*lost || !strings.HasSuffix(req.URL.Path, "/message") {
+			return resp, err
+		}
+		resp.Body.Close() //nolint:errcheck
+		resp.StatusCode = http.StatusServiceUnavailable
+		resp.Body = io.NopCloser(strings.NewReader(`{"is_fatal":true,"status":"503 Service Unavailable"}`))
+		return resp, nil
+	})
+}
+
+func TestSendMessage_IdempotencyKey(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	srv, client := fakeSetup(t)
+	destID := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("oncall", "oncall@example.com"))
+	})
+	groupID := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewGroupOp(client).Create(ctx, fakeGroup("oncall", destID))
+	})
+	history := simplenotification.NewHistoryOp(client)
+	store := simplenotification.NewMemoryIdempotencyStore(time.Hour)
+	request := v1.SendNotificationMessageRequest{Message: "disk full on db1"}
+
+	// the response of the first attempt is lost, but the message is found in the history
+	lost := true
+	groupAPI := simplenotification.NewGroupOp(lostResponseClient(t, srv, &lost))
+	res, err := simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey("alert-1", store, history))
+	assert.NoError(err)
+	assert.True(res.IsOk)
+	record, ok, err := store.Load(ctx, "alert-1")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.True(record.Confirmed)
+	assert.NotEmpty(record.RequestID)
+
+	// a retry of the pipeline is suppressed and reported
+	lost = false
+	_, err = simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey("alert-1", store, history))
+	var dup *simplenotification.DuplicateMessageError
+	assert.ErrorAs(err, &dup)
+	assert.Equal("alert-1", dup.Key)
+	assert.Equal(record.RequestID, dup.RequestID)
+	histories, err := history.List(ctx)
+	assert.NoError(err)
+	assert.Len(histories.NotificationHistories, 1)
+
+	// another key is sent
+	_, err = simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey("alert-2", store, history))
+	assert.NoError(err)
+	histories, err = history.List(ctx)
+	assert.NoError(err)
+	assert.Len(histories.NotificationHistories, 2)
+}
+
+func TestSendMessage_IdempotencyKeyPending(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	groupAPI := simplenotification.NewGroupOp(client)
+	destID := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("oncall", "oncall@example.com"))
+	})
+	groupID := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("oncall", destID))
+	})
+	history := simplenotification.NewHistoryOp(client)
+	store := simplenotification.NewMemoryIdempotencyStore(time.Hour)
+	request := v1.SendNotificationMessageRequest{Message: "disk full on db1"}
+
+	// an attempt with an unknown outcome, not in the history, is sent again
+	assert.NoError(store.Save(ctx, "alert-1", simplenotification.IdempotencyRecord{AttemptedAt: time.Now()}))
+	_, err := simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey("alert-1", store, history))
+	assert.NoError(err)
+
+	// an attempt with an unknown outcome found in the history is not
+	assert.NoError(store.Save(ctx, "alert-2", simplenotification.IdempotencyRecord{AttemptedAt: time.Now()}))
+	request = v1.SendNotificationMessageRequest{Message: "disk full on db2"}
+	_, err = groupAPI.SendMessage(ctx, groupID, request)
+	assert.NoError(err)
+	_, err = simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey("alert-2", store, history))
+	var dup *simplenotification.DuplicateMessageError
+	assert.ErrorAs(err, &dup)
+	assert.NotEmpty(dup.RequestID)
+
+	// the same message sent to another group does not count
+	otherID := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("other", destID))
+	})
+	request = v1.SendNotificationMessageRequest{Message: "disk full on db3"}
+	_, err = groupAPI.SendMessage(ctx, otherID, request)
+	assert.NoError(err)
+	assert.NoError(store.Save(ctx, "alert-6", simplenotification.IdempotencyRecord{AttemptedAt: time.Now()}))
+	_, err = simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey("alert-6", store, history))
+	assert.NoError(err)
+
+	// an attempt still in flight is not sent again, unless its sender stopped long ago
+	request = v1.SendNotificationMessageRequest{Message: "disk full on db4"}
+	assert.NoError(store.Save(ctx, "alert-4", simplenotification.IdempotencyRecord{AttemptedAt: time.Now(), InFlight: true}))
+	_, err = simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey("alert-4", store, history))
+	assert.ErrorAs(err, &dup)
+	assert.True(dup.InFlight)
+	assert.NoError(store.Save(ctx, "alert-5", simplenotification.IdempotencyRecord{AttemptedAt: time.Now().Add(-10 * time.Minute), InFlight: true}))
+	_, err = simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey("alert-5", store, history))
+	assert.NoError(err)
+
+	// a message rejected by the server is forgotten
+	_, err = simplenotification.SendMessage(ctx, groupAPI, "123456789012", request, simplenotification.WithIdempotencyKey("alert-3", store, history))
+	assert.True(saclient.IsNotFoundError(err))
+	_, ok, err := store.Load(ctx, "alert-3")
+	assert.NoError(err)
+	assert.False(ok)
+}
+
+func TestSendMessage_IdempotencyKeyConcurrent(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	groupAPI := simplenotification.NewGroupOp(client)
+	history := simplenotification.NewHistoryOp(client)
+	destID := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("oncall", "oncall@example.com"))
+	})
+	groupID := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("oncall", destID))
+	})
+	request := v1.SendNotificationMessageRequest{Message: "disk full on db1"}
+
+	for name, store := range map[string]simplenotification.IdempotencyStore{
+		"memory": simplenotification.NewMemoryIdempotencyStore(time.Hour),
+		"file":   simplenotification.NewFileIdempotencyStore(filepath.Join(t.TempDir(), "keys.json"), time.Hour),
+	} {
+		key := "alert-" + name
+		var wg sync.WaitGroup
+		errs := make([]error, 8)
+		for i := range errs {
+			wg.Go(func() {
+				_, errs[i] = simplenotification.SendMessage(ctx, groupAPI, groupID, request, simplenotification.WithIdempotencyKey(key, store, history))
+			})
+		}
+		wg.Wait()
+		sent := 0
+		for _, err := range errs {
+			var dup *simplenotification.DuplicateMessageError
+			if err == nil {
+				sent++
+			} else {
+				assert.ErrorAs(err, &dup)
+			}
+		}
+		assert.Equal(1, sent, name)
+	}
+	histories, err := history.List(ctx)
+	assert.NoError(err)
+	assert.Len(histories.NotificationHistories, 2)
+}
+
+func TestFileIdempotencyStore(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "keys.json")
+
+	store := simplenotification.NewFileIdempotencyStore(path, time.Hour)
+	_, ok, err := store.Load(ctx, "alert-1")
+	assert.NoError(err)
+	assert.False(ok)
+	sentAt := time.Now().Truncate(time.Second)
+	assert.NoError(store.Save(ctx, "alert-1", simplenotification.IdempotencyRecord{AttemptedAt: sentAt, Confirmed: true}))
+	assert.NoError(store.Save(ctx, "alert-2", simplenotification.IdempotencyRecord{AttemptedAt: sentAt.Add(-2 * time.Hour), Confirmed: true}))
+	assert.NoError(store.Save(ctx, "alert-3", simplenotification.IdempotencyRecord{AttemptedAt: sentAt}))
+	assert.NoError(store.Delete(ctx, "alert-3"))
+
+	// another process reads the keys of the window
+	store = simplenotification.NewFileIdempotencyStore(path, time.Hour)
+	record, ok, err := store.Load(ctx, "alert-1")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.True(record.Confirmed)
+	assert.True(sentAt.Equal(record.AttemptedAt))
+	_, ok, err = store.Load(ctx, "alert-2")
+	assert.NoError(err)
+	assert.False(ok)
+	_, ok, err = store.Load(ctx, "alert-3")
+	assert.NoError(err)
+	assert.False(ok)
+}
+
+func TestMemoryIdempotencyStore_Window(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	store := simplenotification.NewMemoryIdempotencyStore(time.Minute)
+	assert.NoError(store.Save(ctx, "old", simplenotification.IdempotencyRecord{AttemptedAt: time.Now().Add(-2 * time.Minute), Confirmed: true}))
+	assert.NoError(store.Save(ctx, "new", simplenotification.IdempotencyRecord{AttemptedAt: time.Now(), Confirmed: true}))
+	_, ok, err := store.Load(ctx, "old")
+	assert.NoError(err)
+	assert.False(ok)
+	_, ok, err = store.Load(ctx, "new")
+	assert.NoError(err)
+	assert.True(ok)
+}
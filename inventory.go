@@ -0,0 +1,183 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"time"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"golang.org/x/sync/errgroup"
+)
+
+type InventoryAPI interface {
+	Inventory(ctx context.Context) (*Inventory, error)
+	Watch(ctx context.Context, interval time.Duration, opts ...WatchOption) iter.Seq2[*WatchEvent, error]
+}
+
+var _ InventoryAPI = (*InventoryOp)(nil)
+
+type InventoryOp struct {
+	destinationAPI DestinationAPI
+	groupAPI       GroupAPI
+	routingAPI     RoutingAPI
+}
+
+func NewInventoryOp(client *v1.Client) InventoryAPI {
+	return &InventoryOp{
+		destinationAPI: NewDestinationOp(client),
+		groupAPI:       NewGroupOp(client),
+		routingAPI:     NewRoutingOp(client),
+	}
+}
+
+// Inventory lists destinations, groups and routings in parallel and returns them as a single snapshot
+func (o *InventoryOp) Inventory(ctx context.Context) (*Inventory, error) {
+	var destinations, groups, routings *v1.ListCommonServiceItemsResponse
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() (err error) {
+		destinations, err = o.destinationAPI.List(ctx)
+		return err
+	})
+	eg.Go(func() (err error) {
+		groups, err = o.groupAPI.List(ctx)
+		return err
+	})
+	eg.Go(func() (err error) {
+		routings, err = o.routingAPI.List(ctx)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return NewInventory(destinations.CommonServiceItems, groups.CommonServiceItems, routings.CommonServiceItems), nil
+}
+
+// Inventory is a snapshot of all notification resources indexed by ID and name.
+// It also holds the reverse indexes from a destination to the groups using it and from a group to the routings targeting it.
+type Inventory struct {
+	Destinations []v1.CommonServiceItem
+	Groups       []v1.CommonServiceItem
+	Routings     []v1.CommonServiceItem
+
+	byID              map[string]v1.CommonServiceItem
+	byName            map[v1.CommonServiceItemProviderClass]map[string][]v1.CommonServiceItem
+	destinationGroups map[string][]v1.CommonServiceItem
+	groupRoutings     map[string][]v1.CommonServiceItem
+}
+
+// NewInventory builds an Inventory from listed resources
+func NewInventory(destinations, groups, routings []v1.CommonServiceItem) *Inventory {
+	inv := &Inventory{
+		Destinations:      destinations,
+		Groups:            groups,
+		Routings:          routings,
+		byID:              make(map[string]v1.CommonServiceItem, len(destinations)+len(groups)+len(routings)),
+		byName:            make(map[v1.CommonServiceItemProviderClass]map[string][]v1.CommonServiceItem, 3),
+		destinationGroups: make(map[string][]v1.CommonServiceItem),
+		groupRoutings:     make(map[string][]v1.CommonServiceItem),
+	}
+	for _, items := range [][]v1.CommonServiceItem{destinations, groups, routings} {
+		for _, item := range items {
+			inv.byID[item.ID] = item
+			names := inv.byName[item.Provider.Class]
+			if names == nil {
+				names = make(map[string][]v1.CommonServiceItem)
+				inv.byName[item.Provider.Class] = names
+			}
+			names[item.Name] = append(names[item.Name], item)
+		}
+	}
+	for _, group := range groups {
+		for _, id := range group.Settings.CommonServiceItemGroupSettings.Destinations {
+			inv.destinationGroups[id] = append(inv.destinationGroups[id], group)
+		}
+	}
+	for _, routing := range routings {
+		id := routing.Settings.CommonServiceItemRoutingSettings.TargetGroupID
+		inv.groupRoutings[id] = append(inv.groupRoutings[id], routing)
+	}
+	return inv
+}
+
+// UnmarshalJSON decodes an Inventory encoded by encoding/json, such as a saved Baseline of Watch, and rebuilds its indexes
+func (inv *Inventory) UnmarshalJSON(data []byte) error {
+	var items struct {
+		Destinations []v1.CommonServiceItem
+		Groups       []v1.CommonServiceItem
+		Routings     []v1.CommonServiceItem
+	}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	*inv = *NewInventory(items.Destinations, items.Groups, items.Routings)
+	return nil
+}
+
+// Item returns the resource of any kind with the ID
+func (inv *Inventory) Item(id string) (v1.CommonServiceItem, bool) {
+	item, ok := inv.byID[id]
+	return item, ok
+}
+
+// Destination returns the destination with the ID
+func (inv *Inventory) Destination(id string) (v1.CommonServiceItem, bool) {
+	return inv.itemOf(v1.CommonServiceItemProviderClassSaknoticedestination, id)
+}
+
+// Group returns the group with the ID
+func (inv *Inventory) Group(id string) (v1.CommonServiceItem, bool) {
+	return inv.itemOf(v1.CommonServiceItemProviderClassSaknoticegroup, id)
+}
+
+// Routing returns the routing with the ID
+func (inv *Inventory) Routing(id string) (v1.CommonServiceItem, bool) {
+	return inv.itemOf(v1.CommonServiceItemProviderClassSaknoticerouting, id)
+}
+
+// DestinationsByName returns the destinations named name. Names are not unique.
+func (inv *Inventory) DestinationsByName(name string) []v1.CommonServiceItem {
+	return inv.byName[v1.CommonServiceItemProviderClassSaknoticedestination][name]
+}
+
+// GroupsByName returns the groups named name. Names are not unique.
+func (inv *Inventory) GroupsByName(name string) []v1.CommonServiceItem {
+	return inv.byName[v1.CommonServiceItemProviderClassSaknoticegroup][name]
+}
+
+// RoutingsByName returns the routings named name. Names are not unique.
+func (inv *Inventory) RoutingsByName(name string) []v1.CommonServiceItem {
+	return inv.byName[v1.CommonServiceItemProviderClassSaknoticerouting][name]
+}
+
+// GroupsOfDestination returns the groups delivering to the destination
+func (inv *Inventory) GroupsOfDestination(destinationID string) []v1.CommonServiceItem {
+	return inv.destinationGroups[destinationID]
+}
+
+// RoutingsOfGroup returns the routings targeting the group
+func (inv *Inventory) RoutingsOfGroup(groupID string) []v1.CommonServiceItem {
+	return inv.groupRoutings[groupID]
+}
+
+func (inv *Inventory) itemOf(class v1.CommonServiceItemProviderClass, id string) (v1.CommonServiceItem, bool) {
+	item, ok := inv.byID[id]
+	if !ok || item.Provider.Class != class {
+		return v1.CommonServiceItem{}, false
+	}
+	return item, true
+}
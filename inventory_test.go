@@ -0,0 +1,102 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"testing"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryOp(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+	routingAPI := simplenotification.NewRoutingOp(client)
+
+	alice := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	bob := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("bob", "bob@example.com"))
+	})
+	unused := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice2@example.com"))
+	})
+	ops := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("ops", alice, bob))
+	})
+	dev := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("dev", alice))
+	})
+	monitor := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return routingAPI.Create(ctx, fakeRouting("monitor", "1", ops))
+	})
+
+	inv, err := simplenotification.NewInventoryOp(client).Inventory(ctx)
+	assert.NoError(err)
+	assert.Len(inv.Destinations, 3)
+	assert.Len(inv.Groups, 2)
+	assert.Len(inv.Routings, 1)
+
+	group, ok := inv.Group(ops)
+	assert.True(ok)
+	assert.Equal("ops", group.Name)
+	_, ok = inv.Destination(ops)
+	assert.False(ok)
+	item, ok := inv.Item(monitor)
+	assert.True(ok)
+	assert.Equal(v1.CommonServiceItemProviderClassSaknoticerouting, item.Provider.Class)
+
+	assert.Len(inv.DestinationsByName("alice"), 2)
+	assert.Empty(inv.GroupsByName("alice"))
+	assert.Equal(dev, inv.GroupsByName("dev")[0].ID)
+	assert.Equal(monitor, inv.RoutingsByName("monitor")[0].ID)
+
+	ids := func(items []v1.CommonServiceItem) []string {
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+	assert.ElementsMatch([]string{ops, dev}, ids(inv.GroupsOfDestination(alice)))
+	assert.Equal([]string{ops}, ids(inv.GroupsOfDestination(bob)))
+	assert.Empty(inv.GroupsOfDestination(unused))
+	assert.Equal([]string{monitor}, ids(inv.RoutingsOfGroup(ops)))
+	assert.Empty(inv.RoutingsOfGroup(dev))
+}
+
+func TestClient_ListCommonServiceItems_Unfiltered(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewGroupOp(client).Create(ctx, fakeGroup("ops", dest))
+	})
+
+	// calling the generated client directly lists every kind
+	res, err := client.ListCommonServiceItems(ctx)
+	assert.NoError(err)
+	assert.Len(res.CommonServiceItems, 2)
+}
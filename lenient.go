@@ -0,0 +1,104 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"errors"
+
+	"github.com/go-faster/jx"
+	"github.com/ogen-go/ogen/validate"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// IsUnknown reports whether item has a Provider.Class or a destination Settings.Type unknown to this version of the package.
+// Such values are kept as raw strings, e.g. string(item.Provider.Class), unless the client uses WithStrictDecoding.
+func IsUnknown(item v1.CommonServiceItem) bool {
+	if item.Provider.Class.Validate() != nil {
+		return true
+	}
+	return item.Settings.Type == v1.CommonServiceItemDestinationSettingsCommonServiceItemSettings &&
+		item.Settings.CommonServiceItemDestinationSettings.Type.Validate() != nil
+}
+
+// rawResponse receives the response body from the middleware for the lenient decode
+type rawResponse struct {
+	body []byte
+}
+
+type lenientResponse[T any] interface {
+	*T
+	Decode(d *jx.Decoder) error
+	Validate() error
+}
+
+// decodeLenient decodes the response again when the generated validation rejected it.
+// Unknown enum values are kept as they are; any other validation failure is still an error.
+// It reports false when err is not a validation error or the body was not captured, i.e. in the strict mode.
+func decodeLenient[T any, P lenientResponse[T]](raw *rawResponse, err error, items func(P) []*v1.CommonServiceItem) (P, bool) {
+	var ve *validate.Error
+	if raw == nil || raw.body == nil || !errors.As(err, &ve) {
+		return nil, false
+	}
+	res, masked := P(new(T)), P(new(T))
+	if res.Decode(jx.DecodeBytes(raw.body)) != nil || masked.Decode(jx.DecodeBytes(raw.body)) != nil {
+		return nil, false
+	}
+	for _, item := range items(masked) {
+		maskUnknown(item)
+	}
+	if masked.Validate() != nil {
+		return nil, false
+	}
+	return res, true
+}
+
+// maskUnknown replaces unknown enum values with known ones so that only the other constraints are validated
+func maskUnknown(item *v1.CommonServiceItem) {
+	if item.Provider.Class.Validate() != nil {
+		item.Provider.Class = v1.CommonServiceItemProviderClassSaknoticedestination
+	}
+	settings := &item.Settings.CommonServiceItemDestinationSettings
+	if item.Settings.Type == v1.CommonServiceItemDestinationSettingsCommonServiceItemSettings && settings.Type.Validate() != nil {
+		settings.Type = v1.CommonServiceItemDestinationSettingsTypeEmail
+	}
+}
+
+func lenientList(raw *rawResponse, err error) (*v1.ListCommonServiceItemsResponse, bool) {
+	return decodeLenient(raw, err, func(res *v1.ListCommonServiceItemsResponse) []*v1.CommonServiceItem {
+		items := make([]*v1.CommonServiceItem, len(res.CommonServiceItems))
+		for i := range res.CommonServiceItems {
+			items[i] = &res.CommonServiceItems[i]
+		}
+		return items
+	})
+}
+
+func lenientCreate(raw *rawResponse, err error) (*v1.CreateCommonServiceItemCreated, bool) {
+	return decodeLenient(raw, err, func(res *v1.CreateCommonServiceItemCreated) []*v1.CommonServiceItem {
+		return []*v1.CommonServiceItem{&res.CommonServiceItem}
+	})
+}
+
+func lenientRead(raw *rawResponse, err error) (*v1.GetCommonServiceItemOK, bool) {
+	return decodeLenient(raw, err, func(res *v1.GetCommonServiceItemOK) []*v1.CommonServiceItem {
+		return []*v1.CommonServiceItem{&res.CommonServiceItem}
+	})
+}
+
+func lenientUpdate(raw *rawResponse, err error) (*v1.UpdateCommonServiceItemOK, bool) {
+	return decodeLenient(raw, err, func(res *v1.UpdateCommonServiceItemOK) []*v1.CommonServiceItem {
+		return []*v1.CommonServiceItem{&res.CommonServiceItem}
+	})
+}
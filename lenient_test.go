@@ -0,0 +1,85 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	emailDestination = `{"ID":"113700000001","Name":"mail","Description":"","Tags":[],"Icon":null,` +
+		`"Settings":{"Type":"email","Value":"a@example.com"},"CreatedAt":"2026-01-01T09:00:00+09:00","ModifiedAt":"2026-01-01T09:00:00+09:00",` +
+		`"Provider":{"Class":"saknoticedestination"}}`
+	slackDestination = `{"ID":"113700000002","Name":"slack","Description":"","Tags":[],"Icon":null,` +
+		`"Settings":{"Type":"slack","Value":"#alerts"},"CreatedAt":"2026-01-01T09:00:00+09:00","ModifiedAt":"2026-01-01T09:00:00+09:00",` +
+		`"Provider":{"Class":"saknoticedestination"}}`
+	// brokenRouting has an unknown class and a PriorityRank out of range
+	brokenRouting = `{"ID":"113700000003","Name":"routing","Description":"","Tags":[],"Icon":null,` +
+		`"Settings":{"MatchLabels":[],"SourceID":"1","TargetGroupID":"113700000004","PriorityRank":0},"CreatedAt":"2026-01-01T09:00:00+09:00","ModifiedAt":"2026-01-01T09:00:00+09:00",` +
+		`"Provider":{"Class":"saknoticefuture"}}`
+)
+
+func lenientTestClient(t *testing.T, body string, opts ...simplenotification.ClientOption) *v1.Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	var saClient saclient.Client
+	client, err := simplenotification.NewClientWithAPIRootURL(&saClient, srv.URL, opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestDestinationOp_List_UnknownType(t *testing.T) {
+	assert := require.New(t)
+	body := `{"From":0,"Count":2,"Total":2,"CommonServiceItems":[` + emailDestination + `,` + slackDestination + `]}`
+
+	res, err := simplenotification.NewDestinationOp(lenientTestClient(t, body)).List(t.Context())
+	assert.NoError(err)
+	assert.Len(res.CommonServiceItems, 2)
+	assert.False(simplenotification.IsUnknown(res.CommonServiceItems[0]))
+	assert.True(simplenotification.IsUnknown(res.CommonServiceItems[1]))
+	assert.Equal("slack", string(res.CommonServiceItems[1].Settings.CommonServiceItemDestinationSettings.Type))
+	assert.Equal("#alerts", res.CommonServiceItems[1].Settings.CommonServiceItemDestinationSettings.Value)
+
+	_, err = simplenotification.NewDestinationOp(lenientTestClient(t, body, simplenotification.WithStrictDecoding())).List(t.Context())
+	assert.ErrorContains(err, "invalid value: slack")
+}
+
+func TestDestinationOp_Read_UnknownType(t *testing.T) {
+	assert := require.New(t)
+
+	res, err := simplenotification.NewDestinationOp(lenientTestClient(t, `{"CommonServiceItem":`+slackDestination+`}`)).Read(t.Context(), "113700000002")
+	assert.NoError(err)
+	assert.True(simplenotification.IsUnknown(res.CommonServiceItem))
+	assert.Equal("113700000002", res.CommonServiceItem.ID)
+}
+
+func TestRoutingOp_Read_OtherValidationError(t *testing.T) {
+	// only unknown enum values are tolerated
+	_, err := simplenotification.NewRoutingOp(lenientTestClient(t, `{"CommonServiceItem":`+brokenRouting+`}`)).Read(t.Context(), "113700000003")
+	require.ErrorContains(t, err, "PriorityRank")
+}
@@ -0,0 +1,210 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/sacloud/saclient-go"
+)
+
+// Redacted replaces the values removed by a RedactRule
+const Redacted = "[REDACTED]"
+
+// RedactRule decides whether the value of a JSON field with the key is logged. It returns the value to log
+// and true when it applies, or false to leave the value to the following rules.
+type RedactRule func(key string, value any) (any, bool)
+
+// RedactKeys returns a RedactRule replacing the values of the keys with Redacted
+func RedactKeys(keys ...string) RedactRule {
+	return func(key string, value any) (any, bool) {
+		if slices.Contains(keys, key) {
+			return Redacted, true
+		}
+		return nil, false
+	}
+}
+
+// RedactPatterns returns a RedactRule replacing the matches of the patterns in any string value with Redacted,
+// for values echoed in other fields such as the error message of a rejected destination
+func RedactPatterns(patterns ...*regexp.Regexp) RedactRule {
+	return func(key string, value any) (any, bool) {
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		redacted := s
+		for _, pattern := range patterns {
+			redacted = pattern.ReplaceAllString(redacted, Redacted)
+		}
+		return redacted, redacted != s
+	}
+}
+
+// EmailPattern and URLPattern match the destination values, email addresses and webhook URLs, wherever they appear.
+// They are shared by DefaultRedactRules, RedactDestinationValue and the recorder of simplenotificationtest.
+var (
+	EmailPattern = regexp.MustCompile(`[\p{L}\p{N}._%+-]+@[\p{L}\p{N}.-]+\.\p{L}{2,}`)
+	URLPattern   = regexp.MustCompile(`https?://[^\s"'<>]+`)
+)
+
+// DefaultRedactRules hide destination values (email addresses and webhook URLs) and message contents,
+// including the addresses and URLs found in other strings such as error messages
+var DefaultRedactRules = []RedactRule{RedactKeys("Value", "Message"), RedactPatterns(EmailPattern, URLPattern)}
+
+// LoggingOption configures LoggingMiddleware
+type LoggingOption func(*loggingConfig)
+
+type loggingConfig struct {
+	bodies bool
+	rules  []RedactRule
+}
+
+// WithBodies logs request and response bodies after redaction
+func WithBodies() LoggingOption {
+	return func(c *loggingConfig) { c.bodies = true }
+}
+
+// WithRedactRules replaces DefaultRedactRules. Without any rule, bodies are logged as they are.
+func WithRedactRules(rules ...RedactRule) LoggingOption {
+	return func(c *loggingConfig) { c.rules = rules }
+}
+
+// LoggingMiddleware returns a saclient middleware logging every request at debug level with logger:
+// method, URL, the JSON filter query, the Provider.Class, status and duration, and optionally the bodies.
+//
+// Pass it with saclient.WithMiddleware before NewClient so that it runs after the filter query is set.
+func LoggingMiddleware(logger *slog.Logger, opts ...LoggingOption) saclient.Middleware {
+	c := loggingConfig{rules: DefaultRedactRules}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		cont, ok := pull()
+		if !ok {
+			return nil, errors.New("middleware not found error")
+		}
+		ctx := req.Context()
+		if !logger.Enabled(ctx, slog.LevelDebug) {
+			return cont(req, pull)
+		}
+
+		u := *req.URL
+		u.RawQuery = ""
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", u.String()),
+		}
+		if req.URL.RawQuery != "" {
+			query, err := url.QueryUnescape(req.URL.RawQuery)
+			if err != nil {
+				query = req.URL.RawQuery
+			}
+			attrs = append(attrs, slog.String("query", query))
+		}
+		if class, err := getContextProviderClass(ctx); err == nil {
+			attrs = append(attrs, slog.String("provider_class", string(class)))
+		}
+		if c.bodies && req.Body != nil {
+			b, err := io.ReadAll(req.Body)
+			_ = req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(b))
+			attrs = append(attrs, slog.String("request_body", redactBody(b, c.rules)))
+		}
+
+		start := time.Now()
+		resp, err := cont(req, pull)
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+		if err != nil {
+			attrs = append(attrs, slog.String("error", redactString("error", err.Error(), c.rules)))
+			logger.LogAttrs(ctx, slog.LevelDebug, "simplenotification request failed", attrs...)
+			return nil, err
+		}
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if c.bodies && resp.Body != nil {
+			b, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(b))
+			attrs = append(attrs, slog.String("response_body", redactBody(b, c.rules)))
+		}
+		logger.LogAttrs(ctx, slog.LevelDebug, "simplenotification request", attrs...)
+		return resp, nil
+	}
+}
+
+// redactBody applies the rules to a JSON body. A body which is not JSON is not logged unless there is no rule.
+func redactBody(b []byte, rules []RedactRule) string {
+	if len(b) == 0 || len(rules) == 0 {
+		return string(b)
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return Redacted
+	}
+	redacted, err := json.Marshal(redactJSON(v, rules))
+	if err != nil {
+		return Redacted
+	}
+	return string(redacted)
+}
+
+func redactJSON(v any, rules []RedactRule) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = redactField(key, value, rules)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactField("", value, rules)
+		}
+	}
+	return v
+}
+
+// redactField applies the first rule applying to the field, or the rules to its content
+func redactField(key string, value any, rules []RedactRule) any {
+	for _, rule := range rules {
+		if replaced, ok := rule(key, value); ok {
+			return replaced
+		}
+	}
+	return redactJSON(value, rules)
+}
+
+// redactString applies the rules to a string logged outside of a body
+func redactString(key, s string, rules []RedactRule) string {
+	if redacted, ok := redactField(key, s, rules).(string); ok {
+		return redacted
+	}
+	return Redacted
+}
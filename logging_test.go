@@ -0,0 +1,151 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+	"github.com/stretchr/testify/require"
+)
+
+func loggingSetup(t *testing.T, level slog.Level, opts ...simplenotification.LoggingOption) (*bytes.Buffer, *v1.Client) {
+	srv := simplenotificationtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level}))
+	var saClient saclient.Client
+	if err := saClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+	if err := saClient.SetWith(saclient.WithMiddleware(simplenotification.LoggingMiddleware(logger, opts...))); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+	client, err := simplenotification.NewClientWithAPIRootURL(&saClient, srv.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return &buf, client
+}
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	d := json.NewDecoder(buf)
+	for d.More() {
+		var r map[string]any
+		require.NoError(t, d.Decode(&r))
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	buf, client := loggingSetup(t, slog.LevelDebug, simplenotification.WithBodies())
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("dest", "alice@example.com"))
+	})
+	group := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("group", dest))
+	})
+	_, err := groupAPI.SendMessage(ctx, group, v1.SendNotificationMessageRequest{Message: "db password is hunter2"})
+	assert.NoError(err)
+	_, err = destinationAPI.List(ctx)
+	assert.NoError(err)
+
+	out := buf.String()
+	assert.NotContains(out, "alice@example.com")
+	assert.NotContains(out, "hunter2")
+
+	records := logRecords(t, buf)
+	assert.Len(records, 4)
+	create := records[0]
+	assert.Equal("DEBUG", create["level"])
+	assert.Equal("POST", create["method"])
+	assert.EqualValues(201, create["status"])
+	assert.Contains(create, "duration")
+	assert.Contains(create["request_body"], `"Value":"[REDACTED]"`)
+	assert.Contains(create["response_body"], `"Name":"dest"`)
+
+	send := records[2]
+	assert.Contains(send["request_body"], `"Message":"[REDACTED]"`)
+	assert.EqualValues(202, send["status"])
+
+	list := records[3]
+	assert.Equal("GET", list["method"])
+	assert.Equal(`{"Filter":{"Provider.Class":"saknoticedestination"}}`, list["query"])
+	assert.Equal("saknoticedestination", list["provider_class"])
+	assert.NotContains(list["url"], "Filter")
+}
+
+func TestLoggingMiddleware_Options(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+
+	t.Run("custom rules", func(t *testing.T) {
+		buf, client := loggingSetup(t, slog.LevelDebug, simplenotification.WithBodies(), simplenotification.WithRedactRules(simplenotification.RedactKeys("Name")))
+		mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+			return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("secret-name", "alice@example.com"))
+		})
+		out := buf.String()
+		assert.Contains(out, "alice@example.com")
+		assert.NotContains(out, "secret-name")
+	})
+	t.Run("echoed values", func(t *testing.T) {
+		buf, client := loggingSetup(t, slog.LevelDebug, simplenotification.WithBodies())
+		destinationAPI := simplenotification.NewDestinationOp(client)
+		// the fake rejects the values and echoes them in the error message
+		_, err := destinationAPI.Create(ctx, fakeDestination("dest", "Alice <alice@example.com>"))
+		assert.Error(err)
+		webhook := fakeDestination("hook", "")
+		webhook.CommonServiceItem.Settings.CommonServiceItemDestinationSettings = v1.CommonServiceItemDestinationSettings{
+			Type:  v1.CommonServiceItemDestinationSettingsTypeWebhook,
+			Value: "https://hooks.example.com/T000/%zz",
+		}
+		_, err = destinationAPI.Create(ctx, webhook)
+		assert.Error(err)
+		out := buf.String()
+		assert.NotContains(out, "alice@example.com")
+		assert.NotContains(out, "hooks.example.com")
+		records := logRecords(t, buf)
+		assert.Len(records, 2)
+		assert.Contains(records[0]["response_body"], "not a valid email address")
+	})
+	t.Run("without bodies", func(t *testing.T) {
+		buf, client := loggingSetup(t, slog.LevelDebug)
+		_, err := simplenotification.NewDestinationOp(client).List(ctx)
+		assert.NoError(err)
+		records := logRecords(t, buf)
+		assert.Len(records, 1)
+		assert.NotContains(records[0], "response_body")
+	})
+	t.Run("disabled level", func(t *testing.T) {
+		buf, client := loggingSetup(t, slog.LevelInfo)
+		_, err := simplenotification.NewDestinationOp(client).List(ctx)
+		assert.NoError(err)
+		assert.Empty(buf.String())
+	})
+}
@@ -32,10 +32,10 @@ const (
 	commonServiceItemPath    = "commonserviceitem"
 	commonServiceItemKey     = "CommonServiceItem"
 	commonServiceItemListKey = "CommonServiceItems"
-	commonServiceItemIconKey = "Icon"
+	simpleNotificationPath   = "simplenotification"
 )
 
-func modifiyMiddleware() saclient.Middleware {
+func modifiyMiddleware(config clientConfig) saclient.Middleware {
 	return func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
 		if err := requestModifier(req); err != nil {
 			return nil, err
@@ -48,7 +48,7 @@ func modifiyMiddleware() saclient.Middleware {
 		if err != nil {
 			return nil, err
 		}
-		if err := responseModifier(req, resp); err != nil {
+		if err := responseModifier(req, resp, !config.strict); err != nil {
 			return nil, err
 		}
 		return resp, nil
@@ -58,9 +58,13 @@ func requestModifier(req *http.Request) error {
 	listpath := strings.TrimSuffix(req.URL.Path, "/")
 
 	// commonserviceitem list API , Provider.Class query param setting
+	// without Provider.Class in the context, e.g. v1.Client called directly, every kind is listed
 	if path.Base(listpath) == commonServiceItemPath &&
 		req.Method == http.MethodGet {
 		providerTarget, err := getContextProviderClass(req.Context())
+		if errors.Is(err, errProviderClassNotSet) {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
@@ -71,9 +75,9 @@ func requestModifier(req *http.Request) error {
 	return nil
 }
 
-func responseModifier(req *http.Request, resp *http.Response) error {
-	// commonserviceitem　list and get check.
-	if !strings.Contains(req.URL.String(), commonServiceItemPath) {
+// responseModifier normalizes CommonServiceItem responses. With lenient, the body is also kept for the lenient decode.
+func responseModifier(req *http.Request, resp *http.Response, lenient bool) error {
+	if resp.Body == nil || !hasCommonServiceItemBody(req, resp) {
 		return nil
 	}
 	body := resp.Body
@@ -84,20 +88,15 @@ func responseModifier(req *http.Request, resp *http.Response) error {
 	if err != nil {
 		return err
 	}
-	// JSON decode
-	var data map[string]interface{}
-	if err := json.Unmarshal(bodyBytes, &data); err != nil {
-		// Unmarshal errro, restore body and return
+	newBody, changed := normalizeBody(bodyBytes)
+	if raw := getContextRawResponse(req.Context()); lenient && raw != nil {
+		raw.body = newBody
+	}
+	if !changed {
+		// keep the original bytes as they are
 		resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
 		return nil
 	}
-	// replace null to ""
-	converted := replaceIconNullWithCommonServiceItem(data)
-	// JSON encode
-	newBody, err := json.Marshal(converted)
-	if err != nil {
-		return err
-	}
 	// replace body
 	resp.Body = io.NopCloser(bytes.NewReader(newBody))
 	resp.ContentLength = int64(len(newBody))
@@ -105,6 +104,18 @@ func responseModifier(req *http.Request, resp *http.Response) error {
 	return nil
 }
 
+// hasCommonServiceItemBody reports whether the response carries CommonServiceItem(s).
+// They are returned by list and create on .../commonserviceitem and by read, update and delete on .../commonserviceitem/{id}.
+// Histories, sources, status and message responses under .../simplenotification are left untouched.
+func hasCommonServiceItemBody(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false
+	}
+	p := strings.TrimSuffix(req.URL.Path, "/")
+	base, parent := path.Base(p), path.Base(path.Dir(p))
+	return base == commonServiceItemPath || (parent == commonServiceItemPath && base != simpleNotificationPath)
+}
+
 type filterQuery struct {
 	Filter map[string]string `json:"Filter"`
 }
@@ -122,32 +133,3 @@ func setJSONOnlyQuery(req *http.Request, providerClass v1.CommonServiceItemProvi
 	req.URL.RawQuery = string(b)
 	return nil
 }
-
-func replaceIconNullWithCommonServiceItem(items map[string]interface{}) map[string]interface{} {
-	//  if Icon value is null , replace with empty object
-	replaceIcon := func(data map[string]interface{}) {
-		if v, ok := data[commonServiceItemIconKey]; ok && v == nil {
-			data[commonServiceItemIconKey] = map[string]interface{}{}
-		}
-	}
-
-	// case : List
-	if itemsList, ok := items[commonServiceItemListKey].([]interface{}); ok {
-		for i, item := range itemsList {
-			if data, ok := item.(map[string]interface{}); ok {
-				replaceIcon(data)
-				itemsList[i] = data
-			}
-		}
-		items[commonServiceItemListKey] = itemsList
-		return items
-	}
-
-	// case : default
-	if data, ok := items[commonServiceItemKey].(map[string]interface{}); ok {
-		replaceIcon(data)
-		items[commonServiceItemKey] = data
-		return items
-	}
-	return items
-}
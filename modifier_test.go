@@ -0,0 +1,123 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseModifier(t *testing.T) {
+	const itemBody = `{"CommonServiceItem":{"Icon":null,"Description":"","Tags":[]}}`
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+		body   string
+		want   string
+	}{
+		{name: "list", method: http.MethodGet, path: "/api/cloud/1.1/commonserviceitem", status: 200, body: `{"CommonServiceItems":[{"Icon":null,"Description":"","Tags":[]}]}`, want: `{"CommonServiceItems":[{"Icon":{},"Description":"","Tags":[]}]}`},
+		{name: "create", method: http.MethodPost, path: "/api/cloud/1.1/commonserviceitem/", status: 201, body: itemBody, want: `{"CommonServiceItem":{"Icon":{},"Description":"","Tags":[]}}`},
+		{name: "read", method: http.MethodGet, path: "/api/cloud/1.1/commonserviceitem/113700000001", status: 200, body: itemBody, want: `{"CommonServiceItem":{"Icon":{},"Description":"","Tags":[]}}`},
+		{name: "delete", method: http.MethodDelete, path: "/api/cloud/1.1/commonserviceitem/113700000001", status: 200, body: itemBody, want: `{"CommonServiceItem":{"Icon":{},"Description":"","Tags":[]}}`},
+		{name: "error", method: http.MethodGet, path: "/api/cloud/1.1/commonserviceitem/113700000001", status: 404, body: itemBody, want: itemBody},
+		{name: "status", method: http.MethodGet, path: "/api/cloud/1.1/commonserviceitem/113700000001/simplenotification/status", status: 200, body: itemBody, want: itemBody},
+		{name: "history", method: http.MethodGet, path: "/api/cloud/1.1/commonserviceitem/simplenotification/history", status: 200, body: itemBody, want: itemBody},
+		{name: "sources", method: http.MethodGet, path: "/api/cloud/1.1/commonserviceitem/simplenotification/sources", status: 200, body: itemBody, want: itemBody},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			resp := &http.Response{
+				StatusCode:    tt.status,
+				Header:        http.Header{"Content-Length": {fmt.Sprint(len(tt.body))}},
+				Body:          io.NopCloser(bytes.NewBufferString(tt.body)),
+				ContentLength: int64(len(tt.body)),
+			}
+			require.NoError(t, responseModifier(req, resp, true))
+			got, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+			require.Equal(t, int64(len(tt.want)), resp.ContentLength)
+			require.Equal(t, fmt.Sprint(len(tt.want)), resp.Header.Get("Content-Length"))
+		})
+	}
+}
+
+// listResponse returns a list response of n items like the ones the API returns
+func listResponse(n int, icon string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `{"From":0,"Count":%d,"Total":%d,"CommonServiceItems":[`, n, n)
+	for i := range n {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"Index":%d,"ID":"%d","Name":"destination-%d","Description":"description of destination-%d",`+
+			`"Settings":{"Type":"email","Value":"user%d@example.com"},"Status":{"State":"available"},`+
+			`"ServiceClass":"saknotice/destination","Availability":"available","CreatedAt":"2026-01-01T09:00:00+09:00",`+
+			`"ModifiedAt":"2026-01-01T09:00:00+09:00","Provider":{"ID":1,"Class":"saknoticedestination","Name":"saknoticedestination","ServiceClass":"cloud/saknotice"},`+
+			`"Icon":%s,"Tags":["production","team-a"]}`, i, 113700000000+i, i, i, i, icon)
+	}
+	b.WriteString(`],"is_ok":true}`)
+	return b.Bytes()
+}
+
+func BenchmarkNormalizeBody(b *testing.B) {
+	for _, n := range []int{100, 1000} {
+		for _, tt := range []struct {
+			name string
+			icon string
+		}{
+			{name: "null", icon: `null`},
+			{name: "set", icon: `{"ID":"112901627732","URL":"https://secure.sakura.ad.jp/cloud/zone/is1a/api/cloud/1.1/icon/112901627732.png"}`},
+		} {
+			body := listResponse(n, tt.icon)
+			b.Run(fmt.Sprintf("items=%d/icon=%s", n, tt.name), func(b *testing.B) {
+				b.SetBytes(int64(len(body)))
+				b.ReportAllocs()
+				for b.Loop() {
+					normalizeBody(body)
+				}
+			})
+		}
+	}
+}
+
+func TestRequestModifier_ProviderClass(t *testing.T) {
+	newRequest := func(ctx context.Context) *http.Request {
+		return httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/cloud/1.1/commonserviceitem", nil)
+	}
+
+	req := newRequest(setContextProviderClass(t.Context(), v1.CommonServiceItemProviderClassSaknoticegroup))
+	require.NoError(t, requestModifier(req))
+	require.Contains(t, req.URL.RawQuery, "saknoticegroup")
+
+	// without Provider.Class every kind is listed
+	req = newRequest(t.Context())
+	require.NoError(t, requestModifier(req))
+	require.Empty(t, req.URL.RawQuery)
+
+	require.ErrorContains(t, requestModifier(newRequest(setContextProviderClass(t.Context(), "saknoticeunknown"))), "invalid Provider.Class")
+	require.ErrorContains(t, requestModifier(newRequest(context.WithValue(t.Context(), providerClassKey, "saknoticegroup"))), "not a string")
+}
@@ -0,0 +1,227 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"strings"
+
+	"github.com/go-faster/jx"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// normalizeRule is a server quirk: a field of CommonServiceItem that comes back null, missing or of another kind
+// and breaks the ogen decoder. Such a field is replaced with, or completed by, the default value.
+type normalizeRule struct {
+	// class limits the rule to a Provider.Class; empty applies to every class.
+	// Settings rules must be limited because the oneOf of Settings is told apart by its fields.
+	class v1.CommonServiceItemProviderClass
+	// path is the dot separated field path from CommonServiceItem
+	path string
+	// kind is the JSON kind the decoder expects
+	kind jx.Type
+	// def is the JSON default value
+	def string
+}
+
+// normalizeRules are the known server quirks. Add a line here for a new one.
+var normalizeRules = []normalizeRule{
+	{path: "Icon", kind: jx.Object, def: `{}`},
+	{path: "Description", kind: jx.String, def: `""`},
+	{path: "Tags", kind: jx.Array, def: `[]`},
+	{class: v1.CommonServiceItemProviderClassSaknoticegroup, path: "Settings.Destinations", kind: jx.Array, def: `[]`},
+	{class: v1.CommonServiceItemProviderClassSaknoticerouting, path: "Settings.MatchLabels", kind: jx.Array, def: `[]`},
+}
+
+// compiledRule is a normalizeRule with the path split into fields
+type compiledRule struct {
+	normalizeRule
+	fields []string
+}
+
+var compiledRules = compileRules(normalizeRules)
+
+func compileRules(rules []normalizeRule) []compiledRule {
+	compiled := make([]compiledRule, len(rules))
+	for i, rule := range rules {
+		compiled[i] = compiledRule{normalizeRule: rule, fields: strings.Split(rule.path, ".")}
+	}
+	return compiled
+}
+
+// normalizeBody applies normalizeRules to CommonServiceItem(s) of a response body with a streaming token copy.
+// Every other value is copied byte for byte. It reports false and returns body as is when nothing is normalized
+// or body is not a JSON object.
+func normalizeBody(body []byte) ([]byte, bool) {
+	return normalizeBodyWith(body, compiledRules)
+}
+
+// normalizeBodyWith is normalizeBody with the rules given
+func normalizeBodyWith(body []byte, rules []compiledRule) ([]byte, bool) {
+	d := jx.DecodeBytes(body)
+	if d.Next() != jx.Object {
+		return body, false
+	}
+	n := normalizer{rules: rules, e: &jx.Encoder{}}
+	n.e.Grow(len(body) + 16)
+	if err := n.root(d); err != nil || !n.changed {
+		return body, false
+	}
+	return n.e.Bytes(), true
+}
+
+type normalizer struct {
+	rules   []compiledRule
+	e       *jx.Encoder
+	changed bool
+	// active marks the rules applying to the CommonServiceItem being copied
+	active []bool
+	// fields is the path of the object being copied
+	fields []string
+}
+
+func (n *normalizer) root(d *jx.Decoder) error {
+	n.e.ObjStart()
+	if err := d.ObjBytes(func(d *jx.Decoder, key []byte) error {
+		n.e.FieldStart(string(key))
+		switch {
+		// case : default
+		case string(key) == commonServiceItemKey && d.Next() == jx.Object:
+			return n.item(d)
+		// case : List
+		case string(key) == commonServiceItemListKey && d.Next() == jx.Array:
+			n.e.ArrStart()
+			if err := d.Arr(func(d *jx.Decoder) error {
+				if d.Next() != jx.Object {
+					return n.copyValue(d)
+				}
+				return n.item(d)
+			}); err != nil {
+				return err
+			}
+			n.e.ArrEnd()
+			return nil
+		}
+		return n.copyValue(d)
+	}); err != nil {
+		return err
+	}
+	n.e.ObjEnd()
+	return nil
+}
+
+func (n *normalizer) item(d *jx.Decoder) error {
+	class, err := providerClass(d)
+	if err != nil {
+		return err
+	}
+	n.active = n.active[:0]
+	for _, rule := range n.rules {
+		n.active = append(n.active, rule.class == "" || string(rule.class) == string(class))
+	}
+	n.fields = n.fields[:0]
+	return n.object(d)
+}
+
+// object copies an object, normalizing the fields of the active rules under the current path
+func (n *normalizer) object(d *jx.Decoder) error {
+	depth := len(n.fields)
+	seen := make([]bool, len(n.rules))
+	n.e.ObjStart()
+	if err := d.ObjBytes(func(d *jx.Decoder, key []byte) error {
+		n.e.FieldStart(string(key))
+		descend := ""
+		for i, rule := range n.rules {
+			if !n.active[i] || !n.under(rule, depth) || rule.fields[depth] != string(key) {
+				continue
+			}
+			if len(rule.fields) > depth+1 {
+				descend = rule.fields[depth]
+				continue
+			}
+			seen[i] = true
+			if d.Next() == rule.kind {
+				return n.copyValue(d)
+			}
+			if err := d.Skip(); err != nil {
+				return err
+			}
+			n.e.Raw([]byte(rule.def))
+			n.changed = true
+			return nil
+		}
+		if descend != "" && d.Next() == jx.Object {
+			n.fields = append(n.fields, descend)
+			err := n.object(d)
+			n.fields = n.fields[:depth]
+			return err
+		}
+		return n.copyValue(d)
+	}); err != nil {
+		return err
+	}
+	// complete missing fields
+	for i, rule := range n.rules {
+		if n.active[i] && !seen[i] && len(rule.fields) == depth+1 && n.under(rule, depth) {
+			n.e.FieldStart(rule.fields[depth])
+			n.e.Raw([]byte(rule.def))
+			n.changed = true
+		}
+	}
+	n.e.ObjEnd()
+	return nil
+}
+
+// under reports whether the rule is for a field below the current path of length depth
+func (n *normalizer) under(rule compiledRule, depth int) bool {
+	if len(rule.fields) <= depth {
+		return false
+	}
+	for i := range depth {
+		if rule.fields[i] != n.fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *normalizer) copyValue(d *jx.Decoder) error {
+	raw, err := d.Raw()
+	if err != nil {
+		return err
+	}
+	n.e.Raw(raw)
+	return nil
+}
+
+// providerClass looks ahead for Provider.Class of the CommonServiceItem without consuming it
+func providerClass(d *jx.Decoder) ([]byte, error) {
+	var class []byte
+	err := d.Capture(func(d *jx.Decoder) error {
+		return d.ObjBytes(func(d *jx.Decoder, key []byte) error {
+			if string(key) != "Provider" || d.Next() != jx.Object {
+				return d.Skip()
+			}
+			return d.ObjBytes(func(d *jx.Decoder, key []byte) error {
+				if string(key) != "Class" || d.Next() != jx.String {
+					return d.Skip()
+				}
+				b, err := d.StrBytes()
+				class = b
+				return err
+			})
+		})
+	})
+	return class, err
+}
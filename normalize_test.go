@@ -0,0 +1,140 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-faster/jx"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBody(t *testing.T) {
+	const (
+		dest    = `"Provider":{"Class":"saknoticedestination"}`
+		group   = `"Provider":{"Class":"saknoticegroup"}`
+		routing = `"Provider":{"Class":"saknoticerouting"}`
+	)
+	tests := []struct {
+		name string
+		in   string
+		// want is empty when the body must be kept as is
+		want string
+	}{
+		{
+			name: "null Icon",
+			in:   `{"CommonServiceItem":{` + dest + `,"Icon":null,"Description":"","Tags":[]},"is_ok":true}`,
+			want: `{"CommonServiceItem":{` + dest + `,"Icon":{},"Description":"","Tags":[]},"is_ok":true}`,
+		},
+		{
+			name: "null Tags",
+			in:   `{"CommonServiceItem":{` + dest + `,"Icon":{},"Description":"","Tags":null}}`,
+			want: `{"CommonServiceItem":{` + dest + `,"Icon":{},"Description":"","Tags":[]}}`,
+		},
+		{
+			name: "missing Tags and Description",
+			in:   `{"CommonServiceItem":{` + dest + `,"Icon":{}}}`,
+			want: `{"CommonServiceItem":{` + dest + `,"Icon":{},"Description":"","Tags":[]}}`,
+		},
+		{
+			name: "null Description",
+			in:   `{"CommonServiceItem":{"Description":null,` + dest + `,"Icon":{},"Tags":[]}}`,
+			want: `{"CommonServiceItem":{"Description":"",` + dest + `,"Icon":{},"Tags":[]}}`,
+		},
+		{
+			name: "null group Destinations",
+			in:   `{"CommonServiceItem":{"Settings":{"Destinations":null},` + group + `,"Icon":{},"Description":"","Tags":[]}}`,
+			want: `{"CommonServiceItem":{"Settings":{"Destinations":[]},` + group + `,"Icon":{},"Description":"","Tags":[]}}`,
+		},
+		{
+			name: "missing routing MatchLabels",
+			in:   `{"CommonServiceItem":{"Settings":{"SourceID":"1","PriorityRank":1},` + routing + `,"Icon":{},"Description":"","Tags":[]}}`,
+			want: `{"CommonServiceItem":{"Settings":{"SourceID":"1","PriorityRank":1,"MatchLabels":[]},` + routing + `,"Icon":{},"Description":"","Tags":[]}}`,
+		},
+		{
+			name: "settings rules are limited to the class",
+			in:   `{"CommonServiceItem":{"Settings":{"Type":"email","Value":"a@example.com"},` + dest + `,"Icon":{},"Description":"","Tags":[]}}`,
+		},
+		{
+			name: "list keeps key order, spacing of values and numbers",
+			in:   `{"Total":2,"CommonServiceItems":[{` + dest + `,"Icon":null,"Description":"","Tags":[],"Big":123456789012345678901},{` + group + `,"Icon":{"ID": "1"},"Description":"","Tags":null,"Settings":{"Destinations":["1"]}}]}`,
+			want: `{"Total":2,"CommonServiceItems":[{` + dest + `,"Icon":{},"Description":"","Tags":[],"Big":123456789012345678901},{` + group + `,"Icon":{"ID": "1"},"Description":"","Tags":[],"Settings":{"Destinations":["1"]}}]}`,
+		},
+		{
+			name: "nothing to normalize",
+			in:   "{\n  \"CommonServiceItem\": {\"Icon\": {\"ID\": \"1\"}, \"Description\": \"\", \"Tags\": []}\n}",
+		},
+		{
+			name: "null outside of items",
+			in:   `{"Icon":null,"CommonServiceItem":{"Settings":{"Icon":null},"Icon":{},"Description":"","Tags":[]}}`,
+		},
+		{
+			name: "not an object",
+			in:   `[{"Icon":null}]`,
+		},
+		{
+			name: "broken json",
+			in:   `{"CommonServiceItem":{"Icon":null`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := normalizeBody([]byte(tt.in))
+			if tt.want == "" {
+				require.False(t, changed)
+				require.Equal(t, tt.in, string(got))
+				return
+			}
+			require.True(t, changed)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestNormalizeBody_Decode(t *testing.T) {
+	// every quirk at once must decode with the generated decoder
+	in := `{"CommonServiceItem":{"ID":"113700000001","Name":"group","Settings":{"Destinations":null},` +
+		`"CreatedAt":"2026-01-01T09:00:00+09:00","ModifiedAt":"2026-01-01T09:00:00+09:00",` +
+		`"Provider":{"Class":"saknoticegroup"},"Icon":null,"Description":null}}`
+	got, changed := normalizeBody([]byte(in))
+	require.True(t, changed)
+
+	var res v1.GetCommonServiceItemOK
+	require.NoError(t, res.Decode(jx.DecodeBytes(got)))
+	require.Equal(t, v1.CommonServiceItemGroupSettingsCommonServiceItemSettings, res.CommonServiceItem.Settings.Type)
+	require.Empty(t, res.CommonServiceItem.Settings.CommonServiceItemGroupSettings.Destinations)
+	require.Empty(t, res.CommonServiceItem.Tags)
+}
+
+func TestNormalizeBody_ManyRules(t *testing.T) {
+	assert := require.New(t)
+	rules := make([]normalizeRule, 70)
+	for i := range rules {
+		rules[i] = normalizeRule{path: fmt.Sprintf("Field%02d", i), kind: jx.Number, def: `0`}
+	}
+	in := `{"CommonServiceItem":{"Provider":{"Class":"saknoticedestination"},`
+	for i := range 69 {
+		in += fmt.Sprintf(`"Field%02d":1,`, i)
+	}
+	in += `"Field69":null}}`
+
+	// the rules past the 64th are applied once like the others
+	out, changed := normalizeBodyWith([]byte(in), compileRules(rules))
+	assert.True(changed)
+	assert.Equal(strings.Replace(in, `"Field69":null`, `"Field69":0`, 1), string(out))
+}
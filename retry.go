@@ -0,0 +1,275 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ht "github.com/ogen-go/ogen/http"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// RetryPolicy controls how the requests of an operation are retried.
+//
+// Idempotent operations, which are reading and updating, are retried on connection errors, 429 and 5xx responses.
+// The others are retried only when the connection failed before the request was sent, so that a message is
+// never sent twice. Deleting is among them: a retry after the first attempt removed the resource would fail
+// with 404, which cannot be told apart from deleting a resource that never existed.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one. Less than 2 disables retries.
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry. It doubles on each retry up to MaxBackoff
+	// and a random jitter of up to half of it is subtracted.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of the operations without WithRetryPolicy or WithOperationRetryPolicy
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 4, MinBackoff: time.Second, MaxBackoff: 30 * time.Second}
+
+// WithRetryPolicy sets the retry policy of every operation.
+// It replaces the generic retry of saclient, whose settings are ignored by the client.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *clientConfig) { c.retryPolicy = policy }
+}
+
+// WithOperationRetryPolicy sets the retry policy of an operation, such as v1.SendNotificationMessageOperation,
+// taking precedence over WithRetryPolicy.
+func WithOperationRetryPolicy(op v1.OperationName, policy RetryPolicy) ClientOption {
+	return func(c *clientConfig) {
+		if c.operationRetryPolicies == nil {
+			c.operationRetryPolicies = map[v1.OperationName]RetryPolicy{}
+		}
+		c.operationRetryPolicies[op] = policy
+	}
+}
+
+// RetryError is returned when an operation failed after being retried.
+// It holds the error of every attempt, a failed response being a *v1.ErrorStatusCode.
+type RetryError struct {
+	Operation v1.OperationName
+	// Attempts are the errors of the attempts in order
+	Attempts []error
+	// Err is the reason why retrying stopped before the last attempt failed, such as the context being canceled
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s failed after %d attempts", e.Operation, len(e.Attempts))
+	for i, err := range e.Attempts {
+		fmt.Fprintf(&buf, "; attempt %d: %v", i+1, err)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&buf, "; %v", e.Err)
+	}
+	return buf.String()
+}
+
+// Unwrap returns the errors of the attempts, the last one first so that errors.As finds the final outcome
+func (e *RetryError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Attempts)+1)
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	for i := len(e.Attempts) - 1; i >= 0; i-- {
+		errs = append(errs, e.Attempts[i])
+	}
+	return errs
+}
+
+// retryClient sends a request again following the retry policy of its operation
+type retryClient struct {
+	client   ht.Client
+	policy   RetryPolicy
+	policies map[v1.OperationName]RetryPolicy
+	sleep    func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryClient(client ht.Client, config clientConfig) *retryClient {
+	return &retryClient{client: client, policy: config.retryPolicy, policies: config.operationRetryPolicies, sleep: sleepContext}
+}
+
+func (c *retryClient) Do(req *http.Request) (*http.Response, error) {
+	op := operationOf(req)
+	policy, ok := c.policies[op]
+	if !ok {
+		policy = c.policy
+	}
+	idempotent := req.Method != http.MethodPost && req.Method != http.MethodDelete
+
+	var attempts []error
+	next := req
+	for attempt := 1; ; attempt++ {
+		resp, err := c.client.Do(next)
+		retry := attempt < policy.MaxAttempts && shouldRetry(req.Context(), idempotent, resp, err)
+		if !retry && (len(attempts) == 0 || (err == nil && resp.StatusCode < http.StatusBadRequest)) {
+			return resp, err
+		}
+		if !retry {
+			attempts = append(attempts, attemptError(resp, err))
+			return nil, &RetryError{Operation: op, Attempts: attempts}
+		}
+		wait, ok := retryAfter(resp)
+		if !ok {
+			wait = policy.backoff(attempt)
+		}
+		attempts = append(attempts, attemptError(resp, err))
+		if err := c.sleep(req.Context(), wait); err != nil {
+			return nil, &RetryError{Operation: op, Attempts: attempts, Err: err}
+		}
+		if next, err = rewindRequest(req); err != nil {
+			return nil, &RetryError{Operation: op, Attempts: attempts, Err: err}
+		}
+	}
+}
+
+// backoff is the wait before the retry following the given attempt: exponential with a jitter
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.MinBackoff
+	for i := 1; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, p.MaxBackoff)
+	if wait <= 0 {
+		return 0
+	}
+	return wait - rand.N(wait/2+1)
+}
+
+func shouldRetry(ctx context.Context, idempotent bool, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isUnreachable(err) || (idempotent && isConnectionError(err))
+	}
+	if !idempotent {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// isConnectionError reports whether the request failed on the network, possibly after it was sent
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// retryAfter reads the Retry-After header of a 429 or 503 response, given in seconds or as a date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// attemptError turns the outcome of a failed attempt into an error, consuming the response
+func attemptError(resp *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	e := &v1.ErrorStatusCode{StatusCode: resp.StatusCode}
+	_ = e.Response.UnmarshalJSON(body)
+	return e
+}
+
+// rewindRequest makes a copy of the request that can be sent again
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, errors.New("request body cannot be sent again")
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
+
+// operationOf finds the operation of a request from its method and path
+func operationOf(req *http.Request) v1.OperationName {
+	_, rest, ok := strings.Cut(req.URL.Path, "/"+commonServiceItemPath)
+	if !ok {
+		return ""
+	}
+	rest = strings.TrimPrefix(rest, "/")
+	switch {
+	case rest == "":
+		if req.Method == http.MethodPost {
+			return v1.CreateCommonServiceItemOperation
+		}
+		return v1.ListCommonServiceItemsOperation
+	case rest == simpleNotificationPath+"/history":
+		return v1.ListNotificationHistoriesOperation
+	case strings.HasPrefix(rest, simpleNotificationPath+"/history/"):
+		return v1.GetNotificationHistoryOperation
+	case rest == simpleNotificationPath+"/sources":
+		return v1.ListSourcesOperation
+	case rest == simpleNotificationPath+"/routing/reorder":
+		return v1.ReorderRoutingOperation
+	}
+	switch _, sub, _ := strings.Cut(rest, "/"); sub {
+	case "":
+		switch req.Method {
+		case http.MethodPut:
+			return v1.UpdateCommonServiceItemOperation
+		case http.MethodDelete:
+			return v1.DeleteCommonServiceItemOperation
+		}
+		return v1.GetCommonServiceItemOperation
+	case simpleNotificationPath + "/status":
+		return v1.GetCommonServiceItemStatusOperation
+	case simpleNotificationPath + "/message":
+		return v1.SendNotificationMessageOperation
+	}
+	return ""
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sacloud/saclient-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/stretchr/testify/require"
+)
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func statusResponse(req *http.Request, code int, header ...string) *http.Response {
+	resp := &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"is_fatal":true,"status":"` + http.StatusText(code) + `","error_msg":"try later"}`)),
+		Request:    req,
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		resp.Header.Set(header[i], header[i+1])
+	}
+	return resp
+}
+
+var dialError = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+func TestRetryClient(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		outcomes []func(req *http.Request) (*http.Response, error)
+		wantCode int
+		wantErr  []string
+		// wantSleeps are the lower bounds of the waits, the jitter taking up to half of the backoff
+		wantSleeps []time.Duration
+	}{
+		{
+			name:   "idempotent call succeeds after retries",
+			method: http.MethodGet,
+			path:   "/commonserviceitem",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) {
+					return statusResponse(req, http.StatusServiceUnavailable, "Retry-After", "7"), nil
+				},
+				func(req *http.Request) (*http.Response, error) { return nil, dialError },
+				func(req *http.Request) (*http.Response, error) { return statusResponse(req, http.StatusOK), nil },
+			},
+			wantCode:   http.StatusOK,
+			wantSleeps: []time.Duration{7 * time.Second, time.Second},
+		},
+		{
+			name:   "idempotent call fails after every attempt",
+			method: http.MethodPut,
+			path:   "/commonserviceitem/123",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) {
+					return statusResponse(req, http.StatusTooManyRequests, "Retry-After", "2"), nil
+				},
+				func(req *http.Request) (*http.Response, error) {
+					return statusResponse(req, http.StatusBadGateway), nil
+				},
+				func(req *http.Request) (*http.Response, error) {
+					return statusResponse(req, http.StatusInternalServerError), nil
+				},
+			},
+			wantErr:    []string{"UpdateCommonServiceItem failed after 3 attempts", "attempt 1: code 429", "attempt 2: code 502", "attempt 3: code 500"},
+			wantSleeps: []time.Duration{2 * time.Second, time.Second},
+		},
+		{
+			name:   "client error is not retried",
+			method: http.MethodGet,
+			path:   "/commonserviceitem/123",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) { return statusResponse(req, http.StatusNotFound), nil },
+			},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "message is not sent again after a server error",
+			method: http.MethodPost,
+			path:   "/commonserviceitem/123/simplenotification/message",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) {
+					return statusResponse(req, http.StatusServiceUnavailable), nil
+				},
+			},
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "resource is not created again after a server error",
+			method: http.MethodPost,
+			path:   "/commonserviceitem",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) {
+					return statusResponse(req, http.StatusInternalServerError), nil
+				},
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "resource is not deleted again after a server error",
+			method: http.MethodDelete,
+			path:   "/commonserviceitem/123",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) {
+					return statusResponse(req, http.StatusBadGateway), nil
+				},
+			},
+			wantCode: http.StatusBadGateway,
+		},
+		{
+			name:   "resource is not deleted again after a broken connection",
+			method: http.MethodDelete,
+			path:   "/commonserviceitem/123",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) { return nil, io.ErrUnexpectedEOF },
+			},
+			wantErr: []string{io.ErrUnexpectedEOF.Error()},
+		},
+		{
+			name:   "resource is deleted again when the connection failed",
+			method: http.MethodDelete,
+			path:   "/commonserviceitem/123",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) { return nil, dialError },
+				func(req *http.Request) (*http.Response, error) { return statusResponse(req, http.StatusOK), nil },
+			},
+			wantCode:   http.StatusOK,
+			wantSleeps: []time.Duration{time.Second / 2},
+		},
+		{
+			name:   "message is not sent again after a broken connection",
+			method: http.MethodPost,
+			path:   "/commonserviceitem/123/simplenotification/message",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) { return nil, io.ErrUnexpectedEOF },
+			},
+			wantErr: []string{io.ErrUnexpectedEOF.Error()},
+		},
+		{
+			name:   "message is sent again when the connection failed",
+			method: http.MethodPost,
+			path:   "/commonserviceitem/123/simplenotification/message",
+			outcomes: []func(req *http.Request) (*http.Response, error){
+				func(req *http.Request) (*http.Response, error) { return nil, dialError },
+				func(req *http.Request) (*http.Response, error) { return statusResponse(req, http.StatusAccepted), nil },
+			},
+			wantCode:   http.StatusAccepted,
+			wantSleeps: []time.Duration{time.Second / 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			var bodies []string
+			var sleeps []time.Duration
+			client := &retryClient{
+				client: doerFunc(func(req *http.Request) (*http.Response, error) {
+					body, err := io.ReadAll(req.Body)
+					assert.NoError(err)
+					bodies = append(bodies, string(body))
+					return tt.outcomes[len(bodies)-1](req)
+				}),
+				policy: policy,
+				sleep: func(ctx context.Context, d time.Duration) error {
+					sleeps = append(sleeps, d)
+					return nil
+				},
+			}
+
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, "https://api.example.com/api/cloud/1.1"+tt.path, strings.NewReader(`{"Message":"hello"}`))
+			assert.NoError(err)
+			resp, err := client.Do(req)
+			assert.Len(bodies, len(tt.outcomes))
+			for _, body := range bodies {
+				assert.Equal(`{"Message":"hello"}`, body)
+			}
+			assert.Len(sleeps, len(tt.wantSleeps))
+			for i, want := range tt.wantSleeps {
+				assert.GreaterOrEqual(sleeps[i], want)
+				assert.LessOrEqual(sleeps[i], 2*want)
+			}
+			if tt.wantErr != nil {
+				for _, want := range tt.wantErr {
+					assert.ErrorContains(err, want)
+				}
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+func TestRetryClient_ContextCanceled(t *testing.T) {
+	assert := require.New(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	calls := 0
+	client := &retryClient{
+		client: doerFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			return statusResponse(req, http.StatusServiceUnavailable, "Retry-After", "3600"), nil
+		}),
+		policy: DefaultRetryPolicy,
+		sleep:  sleepContext,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com/commonserviceitem", nil)
+	assert.NoError(err)
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	_, err = client.Do(req)
+	assert.Equal(1, calls)
+	assert.ErrorIs(err, context.Canceled)
+	var retryErr *RetryError
+	assert.ErrorAs(err, &retryErr)
+	assert.Len(retryErr.Attempts, 1)
+}
+
+func TestRetry_Operations(t *testing.T) {
+	assert := require.New(t)
+	calls := map[string]int{}
+	var saClient saclient.Client
+	assert.NoError(saClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}))
+	assert.NoError(saClient.SetWith(saclient.WithMiddleware(func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		calls[req.Method+" "+req.URL.Path]++
+		return statusResponse(req, http.StatusServiceUnavailable), nil
+	})))
+	client, err := NewClientWithAPIRootURL(&saClient, "https://api.example.com/",
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		WithOperationRetryPolicy(v1.GetCommonServiceItemOperation, RetryPolicy{MaxAttempts: 2}))
+	assert.NoError(err)
+	ctx := t.Context()
+
+	// every attempt is in the error, which keeps the status of the last one
+	_, err = NewDestinationOp(client).List(ctx)
+	assert.Error(err)
+	var apiErr *saclient.Error
+	assert.ErrorAs(err, &apiErr)
+	assert.Contains(apiErr.Error(), "API Error 503")
+	var retryErr *RetryError
+	assert.ErrorAs(err, &retryErr)
+	assert.Equal(v1.ListCommonServiceItemsOperation, retryErr.Operation)
+	assert.Len(retryErr.Attempts, 3)
+	for _, attempt := range retryErr.Attempts {
+		var statusErr *v1.ErrorStatusCode
+		assert.ErrorAs(attempt, &statusErr)
+		assert.Equal(http.StatusServiceUnavailable, statusErr.StatusCode)
+		assert.Equal("try later", statusErr.Response.ErrorMsg.Value)
+	}
+
+	_, err = NewGroupOp(client).Read(ctx, "123456789012")
+	assert.Error(err)
+	_, err = NewGroupOp(client).SendMessage(ctx, "123456789012", v1.SendNotificationMessageRequest{Message: "hello"})
+	assert.Error(err)
+	assert.False(errors.As(err, &retryErr))
+
+	assert.Equal(map[string]int{
+		"GET /commonserviceitem":                                          3,
+		"GET /commonserviceitem/123456789012":                             2,
+		"POST /commonserviceitem/123456789012/simplenotification/message": 1,
+	}, calls)
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		for range 100 {
+			got := policy.backoff(attempt + 1)
+			require.GreaterOrEqual(t, got, want/2)
+			require.LessOrEqual(t, got, want)
+		}
+	}
+	require.Zero(t, RetryPolicy{}.backoff(1))
+}
+
+func TestOperationOf(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   v1.OperationName
+	}{
+		{http.MethodGet, "/commonserviceitem", v1.ListCommonServiceItemsOperation},
+		{http.MethodPost, "/commonserviceitem", v1.CreateCommonServiceItemOperation},
+		{http.MethodGet, "/commonserviceitem/123", v1.GetCommonServiceItemOperation},
+		{http.MethodPut, "/commonserviceitem/123", v1.UpdateCommonServiceItemOperation},
+		{http.MethodDelete, "/commonserviceitem/123", v1.DeleteCommonServiceItemOperation},
+		{http.MethodGet, "/commonserviceitem/123/simplenotification/status", v1.GetCommonServiceItemStatusOperation},
+		{http.MethodPost, "/commonserviceitem/123/simplenotification/message", v1.SendNotificationMessageOperation},
+		{http.MethodGet, "/commonserviceitem/simplenotification/history", v1.ListNotificationHistoriesOperation},
+		{http.MethodGet, "/commonserviceitem/simplenotification/history/abc", v1.GetNotificationHistoryOperation},
+		{http.MethodGet, "/commonserviceitem/simplenotification/sources", v1.ListSourcesOperation},
+		{http.MethodPut, "/commonserviceitem/simplenotification/routing/reorder", v1.ReorderRoutingOperation},
+		{http.MethodGet, "/server", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, "https://secure.sakura.ad.jp/cloud/zone/is1a/api/cloud/1.1"+tt.path, nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, operationOf(req))
+		})
+	}
+}
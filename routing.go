@@ -45,8 +45,12 @@ func (o *RoutingOp) List(ctx context.Context) (*v1.ListCommonServiceItemsRespons
 	const methodName = "Routing.List"
 	ctx = setContextProviderClass(ctx, v1.CommonServiceItemProviderClassSaknoticerouting)
 
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.ListCommonServiceItems(ctx)
 	if err != nil {
+		if res, ok := lenientList(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -61,8 +65,12 @@ func (o *RoutingOp) Create(ctx context.Context, request v1.PostCommonServiceItem
 	request.CommonServiceItem.Provider.Class = v1.PostCommonServiceItemRequestCommonServiceItemProviderClassSaknoticerouting
 	request.CommonServiceItem.Settings.Type = v1.CommonServiceItemRoutingSettingsPostCommonServiceItemRequestCommonServiceItemSettings
 
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.CreateCommonServiceItem(ctx, v1.OptPostCommonServiceItemRequest{Value: request, Set: true})
 	if err != nil {
+		if res, ok := lenientCreate(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -75,8 +83,12 @@ func (o *RoutingOp) Create(ctx context.Context, request v1.PostCommonServiceItem
 func (o *RoutingOp) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
 	const methodName = "Routing.Read"
 
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.GetCommonServiceItem(ctx, v1.GetCommonServiceItemParams{ID: id})
 	if err != nil {
+		if res, ok := lenientRead(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
@@ -90,8 +102,12 @@ func (o *RoutingOp) Update(ctx context.Context, id string, request v1.PutCommonS
 	const methodName = "Routing.Update"
 	request.CommonServiceItem.Settings.Value.Type = v1.CommonServiceItemRoutingSettingsPutCommonServiceItemRequestCommonServiceItemSettings
 
+	ctx, raw := setContextRawResponse(ctx)
 	res, err := o.client.UpdateCommonServiceItem(ctx, v1.OptPutCommonServiceItemRequest{Value: request, Set: true}, v1.UpdateCommonServiceItemParams{ID: id})
 	if err != nil {
+		if res, ok := lenientUpdate(raw, err); ok {
+			return res, nil
+		}
 		var e *v1.ErrorStatusCode
 		if errors.As(err, &e) {
 			return nil, NewAPIError(methodName, e.StatusCode, err)
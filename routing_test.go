@@ -19,8 +19,6 @@ import (
 	"os"
 	"testing"
 
-	"github.com/sacloud/packages-go/testutil"
-	"github.com/sacloud/saclient-go"
 	simplenotification "github.com/sacloud/simple-notification-api-go"
 	"github.com/stretchr/testify/require"
 
@@ -28,24 +26,14 @@ import (
 )
 
 func routingAPISetup(t *testing.T) (ctx context.Context, api simplenotification.RoutingAPI) {
-	testutil.PreCheckEnvsFunc("SAKURA_ACCESS_TOKEN", "SAKURA_ACCESS_TOKEN_SECRET")(t)
-
 	ctx = t.Context()
-
-	var saClient saclient.Client
-
-	client, err := simplenotification.NewClient(&saClient)
-	if err != nil {
-		t.Fatalf("failed to create client: %v", err)
-	}
-	api = simplenotification.NewRoutingOp(client)
+	api = simplenotification.NewRoutingOp(newTestClient(t, "SAKURA_ROUTING_TEST_LABEL", "SAKURA_ROUTING_TEST_LABELVAL", "SAKURA_ROUTING_TEST_SOURCEID", "SAKURA_ROUTING_TEST_TARGETGROUPID"))
 
 	return ctx, api
 }
 
 func TestRoutingOp(t *testing.T) {
 	ctx, routingAPI := routingAPISetup(t)
-	testutil.PreCheckEnvsFunc("SAKURA_ROUTING_TEST_LABEL", "SAKURA_ROUTING_TEST_LABELVAL", "SAKURA_ROUTING_TEST_SOURCEID", "SAKURA_ROUTING_TEST_TARGETGROUPID")(t)
 	id := "" // set created routing ID
 	routingName := "test-routing-1"
 	description := "test-routing-description"
@@ -0,0 +1,298 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simplenotificationanalytics computes delivery statistics from notification histories.
+//
+// Feed an Analyzer with histories listed by the API or read from an archive, then take its Report:
+//
+//	analyzer := simplenotificationanalytics.New()
+//	if err := analyzer.AddFrom(ctx, simplenotification.NewHistoryOp(client)); err != nil {
+//		return err
+//	}
+//	report := analyzer.Report()
+//	report.WriteCSV(os.Stdout)
+//
+// Each NotificationStatus of a history is a delivery, counted for its destination, its group and the source of the history.
+package simplenotificationanalytics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// DefaultPercentiles are the latency percentiles computed without WithPercentiles
+var DefaultPercentiles = []float64{50, 90, 99}
+
+// DefaultTopErrors is the number of error messages reported without WithTopErrors
+const DefaultTopErrors = 10
+
+// Option configures an Analyzer
+type Option func(*Analyzer)
+
+// WithPercentiles replaces the latency percentiles, between 0 and 100
+func WithPercentiles(percentiles ...float64) Option {
+	return func(a *Analyzer) { a.percentiles = slices.Clone(percentiles) }
+}
+
+// WithTopErrors sets the number of most frequent error messages reported
+func WithTopErrors(n int) Option {
+	return func(a *Analyzer) { a.topErrors = n }
+}
+
+// Analyzer accumulates notification histories. A history added again replaces the earlier one,
+// so that the same history from an archive and from the API, or listed before and after its delivery, is counted once.
+type Analyzer struct {
+	percentiles []float64
+	topErrors   int
+	histories   map[string]v1.NotificationHistory
+}
+
+// New creates an empty Analyzer
+func New(opts ...Option) *Analyzer {
+	a := &Analyzer{
+		percentiles: DefaultPercentiles,
+		topErrors:   DefaultTopErrors,
+		histories:   map[string]v1.NotificationHistory{},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Add adds histories
+func (a *Analyzer) Add(histories ...v1.NotificationHistory) {
+	for _, history := range histories {
+		a.histories[history.RequestID] = history
+	}
+}
+
+// AddFrom adds the histories listed by the API, which returns the latest ones only
+func (a *Analyzer) AddFrom(ctx context.Context, api simplenotification.HistoryAPI) error {
+	res, err := api.List(ctx)
+	if err != nil {
+		return err
+	}
+	a.Add(res.NotificationHistories...)
+	return nil
+}
+
+// AddArchive adds the histories of an archive holding JSON values one after another, each of them a history,
+// an array of histories or a response of the history list API. JSON Lines of histories are such an archive.
+func (a *Analyzer) AddArchive(r io.Reader) error {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	for n := 1; ; n++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("reading value %d of the archive: %w", n, err)
+		}
+		histories, err := decodeHistories(raw)
+		if err != nil {
+			return fmt.Errorf("reading value %d of the archive: %w", n, err)
+		}
+		a.Add(histories...)
+	}
+}
+
+func decodeHistories(raw json.RawMessage) ([]v1.NotificationHistory, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '[' {
+		var histories []v1.NotificationHistory
+		err := json.Unmarshal(raw, &histories)
+		return histories, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["NotificationHistories"]; ok {
+		var res v1.ListSimpleNotificationHistoriesResponse
+		err := json.Unmarshal(raw, &res)
+		return res.NotificationHistories, err
+	}
+	var history v1.NotificationHistory
+	if err := json.Unmarshal(raw, &history); err != nil {
+		return nil, err
+	}
+	return []v1.NotificationHistory{history}, nil
+}
+
+// Report computes the statistics of the histories added so far
+func (a *Analyzer) Report() *Report {
+	destinations := newStatsSet(a.percentiles)
+	groups := newStatsSet(a.percentiles)
+	sources := newStatsSet(a.percentiles)
+	errorCounts := map[string]*ErrorCount{}
+
+	report := &Report{Percentiles: a.percentiles}
+	for _, history := range a.histories {
+		report.Histories++
+		for _, status := range history.Statuses {
+			report.Deliveries++
+			destinations.add(status.DestinationID, history, status)
+			groups.add(status.GroupID, history, status)
+			sources.add(history.SourceID, history, status)
+			info := strings.TrimSpace(status.ErrorInfo)
+			if info == "" {
+				continue
+			}
+			e, ok := errorCounts[info]
+			if !ok {
+				e = &ErrorCount{ErrorInfo: info}
+				errorCounts[info] = e
+			}
+			e.Count++
+			if status.UpdatedAt.After(e.LastSeen) {
+				e.LastSeen = status.UpdatedAt
+			}
+		}
+	}
+	report.Destinations = destinations.sorted()
+	report.Groups = groups.sorted()
+	report.Sources = sources.sorted()
+
+	for _, e := range errorCounts {
+		report.TopErrors = append(report.TopErrors, *e)
+	}
+	slices.SortFunc(report.TopErrors, func(a, b ErrorCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.ErrorInfo, b.ErrorInfo)
+	})
+	if len(report.TopErrors) > a.topErrors {
+		report.TopErrors = report.TopErrors[:max(a.topErrors, 0)]
+	}
+	return report
+}
+
+// Report is the statistics of deliveries per destination, group and source
+type Report struct {
+	Histories  int `json:"histories"`
+	Deliveries int `json:"deliveries"`
+	// Percentiles are the percentiles of the latencies of each Stats
+	Percentiles  []float64    `json:"percentiles"`
+	Destinations []Stats      `json:"destinations"`
+	Groups       []Stats      `json:"groups"`
+	Sources      []Stats      `json:"sources"`
+	TopErrors    []ErrorCount `json:"top_errors"`
+}
+
+// Stats are the deliveries of a destination, a group or a source
+type Stats struct {
+	ID string `json:"id"`
+	// Total is the number of deliveries, Pending, Sending, Sent and Failed the ones with status 0, 1, 2 and 9
+	Total   int `json:"total"`
+	Pending int `json:"pending"`
+	Sending int `json:"sending"`
+	Sent    int `json:"sent"`
+	Failed  int `json:"failed"`
+	// FailureRate is the rate of the failed deliveries among the finished ones, zero without any
+	FailureRate float64 `json:"failure_rate"`
+	// Latencies are the percentiles of the time from ReceivedAt of the history to UpdatedAt of the sent deliveries,
+	// empty without a sent delivery
+	Latencies []Latency `json:"latencies"`
+}
+
+// Latency is a percentile of the delivery latencies
+type Latency struct {
+	Percentile float64
+	Duration   time.Duration
+}
+
+// MarshalJSON encodes the duration in seconds
+func (l Latency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Percentile float64 `json:"percentile"`
+		Seconds    float64 `json:"seconds"`
+	}{l.Percentile, l.Duration.Seconds()})
+}
+
+// ErrorCount is an error message of deliveries and the number of times it occurred
+type ErrorCount struct {
+	ErrorInfo string    `json:"error_info"`
+	Count     int       `json:"count"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+type statsSet struct {
+	percentiles []float64
+	stats       map[string]*Stats
+	latencies   map[string][]time.Duration
+}
+
+func newStatsSet(percentiles []float64) *statsSet {
+	return &statsSet{percentiles: percentiles, stats: map[string]*Stats{}, latencies: map[string][]time.Duration{}}
+}
+
+func (s *statsSet) add(id string, history v1.NotificationHistory, status v1.NotificationStatus) {
+	if id == "" {
+		return
+	}
+	stats, ok := s.stats[id]
+	if !ok {
+		stats = &Stats{ID: id}
+		s.stats[id] = stats
+	}
+	stats.Total++
+	switch status.Status {
+	case v1.NotificationStatusStatus0:
+		stats.Pending++
+	case v1.NotificationStatusStatus1:
+		stats.Sending++
+	case v1.NotificationStatusStatus2:
+		stats.Sent++
+		s.latencies[id] = append(s.latencies[id], max(status.UpdatedAt.Sub(history.ReceivedAt), 0))
+	case v1.NotificationStatusStatus9:
+		stats.Failed++
+	}
+}
+
+// sorted returns the statistics sorted by ID
+func (s *statsSet) sorted() []Stats {
+	all := make([]Stats, 0, len(s.stats))
+	for id, stats := range s.stats {
+		if finished := stats.Sent + stats.Failed; finished > 0 {
+			stats.FailureRate = float64(stats.Failed) / float64(finished)
+		}
+		if latencies := s.latencies[id]; len(latencies) > 0 {
+			slices.Sort(latencies)
+			for _, p := range s.percentiles {
+				stats.Latencies = append(stats.Latencies, Latency{Percentile: p, Duration: percentile(latencies, p)})
+			}
+		}
+		all = append(all, *stats)
+	}
+	slices.SortFunc(all, func(a, b Stats) int { return strings.Compare(a.ID, b.ID) })
+	return all
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank-1, 0), len(sorted)-1)]
+}
@@ -0,0 +1,152 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationanalytics_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationanalytics"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationmock"
+	"github.com/stretchr/testify/require"
+)
+
+var received = time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+func history(id, source string, statuses ...v1.NotificationStatus) v1.NotificationHistory {
+	return v1.NotificationHistory{RequestID: id, SourceID: source, ReceivedAt: received, Statuses: statuses}
+}
+
+func status(group, destination string, s v1.NotificationStatusStatus, latency time.Duration, errorInfo string) v1.NotificationStatus {
+	return v1.NotificationStatus{GroupID: group, DestinationID: destination, Status: s, UpdatedAt: received.Add(latency), ErrorInfo: errorInfo}
+}
+
+func sampleHistories() []v1.NotificationHistory {
+	const sent, failed, sending = v1.NotificationStatusStatus2, v1.NotificationStatusStatus9, v1.NotificationStatusStatus1
+	return []v1.NotificationHistory{
+		history("r1", "1", status("g1", "mail", sent, 1*time.Second, ""), status("g1", "hook", failed, 3*time.Second, "503 Service Unavailable")),
+		history("r2", "1", status("g1", "mail", sent, 2*time.Second, ""), status("g1", "hook", failed, 3*time.Second, "503 Service Unavailable")),
+		history("r3", "2", status("g2", "mail", sent, 3*time.Second, ""), status("g2", "hook", sent, 1*time.Second, "")),
+		history("r4", "2", status("g2", "mail", sent, 4*time.Second, ""), status("g2", "hook", failed, 0, " timeout ")),
+		history("r5", "2", status("g2", "mail", sending, 0, "")),
+	}
+}
+
+func TestAnalyzer_Report(t *testing.T) {
+	assert := require.New(t)
+	analyzer := simplenotificationanalytics.New(simplenotificationanalytics.WithTopErrors(1))
+	analyzer.Add(sampleHistories()...)
+	// a history added again replaces the earlier one
+	analyzer.Add(sampleHistories()[4])
+
+	report := analyzer.Report()
+	assert.Equal(5, report.Histories)
+	assert.Equal(9, report.Deliveries)
+
+	assert.Len(report.Destinations, 2)
+	hook, mail := report.Destinations[0], report.Destinations[1]
+	assert.Equal(simplenotificationanalytics.Stats{
+		ID: "hook", Total: 4, Sent: 1, Failed: 3, FailureRate: 0.75,
+		Latencies: []simplenotificationanalytics.Latency{{Percentile: 50, Duration: time.Second}, {Percentile: 90, Duration: time.Second}, {Percentile: 99, Duration: time.Second}},
+	}, hook)
+	assert.Equal(5, mail.Total)
+	assert.Equal(1, mail.Sending)
+	assert.Equal(0.0, mail.FailureRate)
+	assert.Equal([]simplenotificationanalytics.Latency{
+		{Percentile: 50, Duration: 2 * time.Second}, {Percentile: 90, Duration: 4 * time.Second}, {Percentile: 99, Duration: 4 * time.Second},
+	}, mail.Latencies)
+
+	assert.Equal([]string{"g1", "g2"}, []string{report.Groups[0].ID, report.Groups[1].ID})
+	assert.Equal(0.5, report.Groups[0].FailureRate)
+	assert.Equal([]string{"1", "2"}, []string{report.Sources[0].ID, report.Sources[1].ID})
+	assert.Equal(5, report.Sources[1].Total)
+
+	assert.Equal([]simplenotificationanalytics.ErrorCount{
+		{ErrorInfo: "503 Service Unavailable", Count: 2, LastSeen: received.Add(3 * time.Second)},
+	}, report.TopErrors)
+}
+
+func TestAnalyzer_AddArchive(t *testing.T) {
+	assert := require.New(t)
+	histories := sampleHistories()
+	var archive bytes.Buffer
+	enc := json.NewEncoder(&archive)
+	// a history per line, an array and a response of the list API
+	assert.NoError(enc.Encode(&histories[0]))
+	assert.NoError(enc.Encode(histories[1:3]))
+	assert.NoError(enc.Encode(&v1.ListSimpleNotificationHistoriesResponse{NotificationHistories: histories[3:]}))
+
+	analyzer := simplenotificationanalytics.New()
+	assert.NoError(analyzer.AddArchive(&archive))
+	assert.Equal(5, analyzer.Report().Histories)
+
+	err := analyzer.AddArchive(strings.NewReader("[]\n" + `{"request_id": "r6"}`))
+	assert.ErrorContains(err, "reading value 2 of the archive")
+}
+
+func TestAnalyzer_AddFrom(t *testing.T) {
+	assert := require.New(t)
+	api := &simplenotificationmock.HistoryAPI{
+		ListFunc: func(ctx context.Context) (*v1.ListSimpleNotificationHistoriesResponse, error) {
+			return &v1.ListSimpleNotificationHistoriesResponse{NotificationHistories: sampleHistories()}, nil
+		},
+	}
+	analyzer := simplenotificationanalytics.New()
+	assert.NoError(analyzer.AddFrom(t.Context(), api))
+	assert.Equal(9, analyzer.Report().Deliveries)
+}
+
+func TestReport_Export(t *testing.T) {
+	assert := require.New(t)
+	analyzer := simplenotificationanalytics.New(simplenotificationanalytics.WithPercentiles(50, 99.9))
+	analyzer.Add(sampleHistories()[:2]...)
+	report := analyzer.Report()
+
+	var buf bytes.Buffer
+	assert.NoError(report.WriteCSV(&buf))
+	assert.Equal(`kind,id,total,pending,sending,sent,failed,failure_rate,p50_seconds,p99.9_seconds
+destination,hook,2,0,0,0,2,1,,
+destination,mail,2,0,0,2,0,0,1,2
+group,g1,4,0,0,2,2,0.5,1,2
+source,1,4,0,0,2,2,0.5,1,2
+`, buf.String())
+
+	buf.Reset()
+	assert.NoError(report.WriteTopErrorsCSV(&buf))
+	assert.Equal("error_info,count,last_seen\n503 Service Unavailable,2,2026-04-01T09:00:03Z\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(report.WriteJSON(&buf))
+	var decoded struct {
+		Destinations []struct {
+			ID        string `json:"id"`
+			Latencies []struct {
+				Percentile float64 `json:"percentile"`
+				Seconds    float64 `json:"seconds"`
+			} `json:"latencies"`
+		} `json:"destinations"`
+		TopErrors []simplenotificationanalytics.ErrorCount `json:"top_errors"`
+	}
+	assert.NoError(json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal("mail", decoded.Destinations[1].ID)
+	assert.Equal(99.9, decoded.Destinations[1].Latencies[1].Percentile)
+	assert.Equal(2.0, decoded.Destinations[1].Latencies[1].Seconds)
+	assert.Equal(2, decoded.TopErrors[0].Count)
+}
@@ -0,0 +1,90 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationanalytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteJSON writes the report as indented JSON, with the latencies in seconds
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes a row per destination, group and source with a header.
+// The latency columns are named after the percentiles, such as p90_seconds, and are empty without a sent delivery.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"kind", "id", "total", "pending", "sending", "sent", "failed", "failure_rate"}
+	for _, p := range r.Percentiles {
+		header = append(header, "p"+formatFloat(p)+"_seconds")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, kind := range []struct {
+		name  string
+		stats []Stats
+	}{
+		{"destination", r.Destinations},
+		{"group", r.Groups},
+		{"source", r.Sources},
+	} {
+		for _, s := range kind.stats {
+			row := []string{
+				kind.name, s.ID,
+				strconv.Itoa(s.Total), strconv.Itoa(s.Pending), strconv.Itoa(s.Sending), strconv.Itoa(s.Sent), strconv.Itoa(s.Failed),
+				formatFloat(s.FailureRate),
+			}
+			for i := range r.Percentiles {
+				if i < len(s.Latencies) {
+					row = append(row, formatFloat(s.Latencies[i].Duration.Seconds()))
+				} else {
+					row = append(row, "")
+				}
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTopErrorsCSV writes a row per error message of TopErrors with a header, the most frequent first
+func (r *Report) WriteTopErrorsCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"error_info", "count", "last_seen"}); err != nil {
+		return err
+	}
+	for _, e := range r.TopErrors {
+		if err := cw.Write([]string{e.ErrorInfo, strconv.Itoa(e.Count), e.LastSeen.Format(time.RFC3339)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,154 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationcache
+
+import (
+	"context"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+var _ simplenotification.DestinationAPI = (*destinationAPI)(nil)
+
+type destinationAPI struct {
+	api   simplenotification.DestinationAPI
+	cache *Cache
+}
+
+// Destination caches the reads of a DestinationAPI. GetStatus is not cached.
+func (c *Cache) Destination(api simplenotification.DestinationAPI) simplenotification.DestinationAPI {
+	return &destinationAPI{api: api, cache: c}
+}
+
+func (a *destinationAPI) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+	return cached(ctx, a.cache, entryKey{kind: KindDestination}, a.api.List)
+}
+
+func (a *destinationAPI) Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+	res, err := a.api.Create(ctx, request)
+	return invalidating(a.cache, KindDestination, res, err)
+}
+
+func (a *destinationAPI) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+	return cached(ctx, a.cache, entryKey{kind: KindDestination, id: id}, func(ctx context.Context) (*v1.GetCommonServiceItemOK, error) {
+		return a.api.Read(ctx, id)
+	})
+}
+
+func (a *destinationAPI) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
+	res, err := a.api.Update(ctx, id, request)
+	return invalidating(a.cache, KindDestination, res, err)
+}
+
+func (a *destinationAPI) Delete(ctx context.Context, id string) error {
+	err := a.api.Delete(ctx, id)
+	a.cache.Invalidate(KindDestination)
+	return err
+}
+
+func (a *destinationAPI) GetStatus(ctx context.Context, id string) (*v1.GetCommonServiceItemStatusResponse, error) {
+	return a.api.GetStatus(ctx, id)
+}
+
+var _ simplenotification.GroupAPI = (*groupAPI)(nil)
+
+type groupAPI struct {
+	api   simplenotification.GroupAPI
+	cache *Cache
+}
+
+// Group caches the reads of a GroupAPI. SendMessage is not cached.
+func (c *Cache) Group(api simplenotification.GroupAPI) simplenotification.GroupAPI {
+	return &groupAPI{api: api, cache: c}
+}
+
+func (a *groupAPI) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+	return cached(ctx, a.cache, entryKey{kind: KindGroup}, a.api.List)
+}
+
+func (a *groupAPI) Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+	res, err := a.api.Create(ctx, request)
+	return invalidating(a.cache, KindGroup, res, err)
+}
+
+func (a *groupAPI) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+	return cached(ctx, a.cache, entryKey{kind: KindGroup, id: id}, func(ctx context.Context) (*v1.GetCommonServiceItemOK, error) {
+		return a.api.Read(ctx, id)
+	})
+}
+
+func (a *groupAPI) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
+	res, err := a.api.Update(ctx, id, request)
+	return invalidating(a.cache, KindGroup, res, err)
+}
+
+func (a *groupAPI) Delete(ctx context.Context, id string) error {
+	err := a.api.Delete(ctx, id)
+	a.cache.Invalidate(KindGroup)
+	return err
+}
+
+func (a *groupAPI) SendMessage(ctx context.Context, id string,
+	request v1.SendNotificationMessageRequest) (*v1.SendNotificationMessageResponse, error) {
+	return a.api.SendMessage(ctx, id, request)
+}
+
+var _ simplenotification.RoutingAPI = (*routingAPI)(nil)
+
+type routingAPI struct {
+	api   simplenotification.RoutingAPI
+	cache *Cache
+}
+
+// Routing caches the reads of a RoutingAPI, including ListSource under KindSource
+func (c *Cache) Routing(api simplenotification.RoutingAPI) simplenotification.RoutingAPI {
+	return &routingAPI{api: api, cache: c}
+}
+
+func (a *routingAPI) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+	return cached(ctx, a.cache, entryKey{kind: KindRouting}, a.api.List)
+}
+
+func (a *routingAPI) Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+	res, err := a.api.Create(ctx, request)
+	return invalidating(a.cache, KindRouting, res, err)
+}
+
+func (a *routingAPI) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+	return cached(ctx, a.cache, entryKey{kind: KindRouting, id: id}, func(ctx context.Context) (*v1.GetCommonServiceItemOK, error) {
+		return a.api.Read(ctx, id)
+	})
+}
+
+func (a *routingAPI) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
+	res, err := a.api.Update(ctx, id, request)
+	return invalidating(a.cache, KindRouting, res, err)
+}
+
+func (a *routingAPI) Delete(ctx context.Context, id string) error {
+	err := a.api.Delete(ctx, id)
+	a.cache.Invalidate(KindRouting)
+	return err
+}
+
+func (a *routingAPI) Reorder(ctx context.Context, request v1.PutCommonServiceItemRoutingReorderRequest) (*v1.ReorderRoutingAccepted, error) {
+	res, err := a.api.Reorder(ctx, request)
+	return invalidating(a.cache, KindRouting, res, err)
+}
+
+func (a *routingAPI) ListSource(ctx context.Context) (*v1.ListSourcesResponse, error) {
+	return cached(ctx, a.cache, entryKey{kind: KindSource}, a.api.ListSource)
+}
@@ -0,0 +1,201 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simplenotificationcache caches the reads of the simple-notification API in memory.
+//
+// Wrap the APIs with a Cache to serve List, Read and ListSource from memory until the TTL of their kind expires:
+//
+//	cache := simplenotificationcache.New(simplenotificationcache.WithTTL(simplenotificationcache.KindSource, 10*time.Minute))
+//	groupAPI := cache.Group(simplenotification.NewGroupOp(client))
+//
+// Concurrent misses of the same entry share one request, unless a write came in between. Create, Update, Delete and the other writes through
+// the wrapped APIs invalidate the entries of their kind. Changes made elsewhere are seen after the TTL
+// or a call of Invalidate. The cached responses are shared between callers and must not be modified.
+package simplenotificationcache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultTTL is the TTL of the kinds without WithTTL
+const DefaultTTL = time.Minute
+
+// Kind is a kind of cached resources
+type Kind string
+
+const (
+	KindDestination Kind = "destination"
+	KindGroup       Kind = "group"
+	KindRouting     Kind = "routing"
+	KindSource      Kind = "source"
+)
+
+// Kinds are all the kinds of cached resources
+var Kinds = []Kind{KindDestination, KindGroup, KindRouting, KindSource}
+
+// Option configures a Cache
+type Option func(*Cache)
+
+// WithTTL sets how long the resources of kind are cached. Zero or less disables the cache of the kind.
+func WithTTL(kind Kind, ttl time.Duration) Option {
+	return func(c *Cache) { c.ttls[kind] = ttl }
+}
+
+// WithClock replaces the clock used for the expiration of the entries
+func WithClock(now func() time.Time) Option {
+	return func(c *Cache) { c.now = now }
+}
+
+// Stats are the counters of the cache of a kind
+type Stats struct {
+	// Hits are the calls served from the cache
+	Hits uint64
+	// Misses are the calls not found in the cache
+	Misses uint64
+	// Fetches are the requests sent on misses, fewer than Misses when concurrent misses share a request
+	Fetches uint64
+}
+
+// Cache holds the entries of the wrapped APIs
+type Cache struct {
+	now    func() time.Time
+	ttls   map[Kind]time.Duration
+	flight singleflight.Group
+
+	mu          sync.Mutex
+	entries     map[entryKey]entry
+	generations map[Kind]uint64
+	stats       map[Kind]Stats
+}
+
+type entryKey struct {
+	kind Kind
+	// id is the ID of a read, empty for a list
+	id string
+}
+
+type entry struct {
+	value   any
+	expires time.Time
+}
+
+// New creates an empty cache
+func New(opts ...Option) *Cache {
+	c := &Cache{
+		now:         time.Now,
+		ttls:        map[Kind]time.Duration{},
+		entries:     map[entryKey]entry{},
+		generations: map[Kind]uint64{},
+		stats:       map[Kind]Stats{},
+	}
+	for _, kind := range Kinds {
+		c.ttls[kind] = DefaultTTL
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Invalidate drops the entries of the kinds, or all of them without a kind
+func (c *Cache) Invalidate(kinds ...Kind) {
+	if len(kinds) == 0 {
+		kinds = Kinds
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, kind := range kinds {
+		c.generations[kind]++
+		for key := range c.entries {
+			if key.kind == kind {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+// Stats returns the counters of each kind
+func (c *Cache) Stats() map[Kind]Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := make(map[Kind]Stats, len(Kinds))
+	for _, kind := range Kinds {
+		stats[kind] = c.stats[kind]
+	}
+	return stats
+}
+
+// lookup returns the entry unless it expired, counting a hit or a miss, with the generation of its kind
+func (c *Cache) lookup(key entryKey) (any, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats[key.kind]
+	defer func() { c.stats[key.kind] = stats }()
+	if e, ok := c.entries[key]; ok && c.now().Before(e.expires) {
+		stats.Hits++
+		return e.value, true, 0
+	}
+	stats.Misses++
+	return nil, false, c.generations[key.kind]
+}
+
+// store counts a fetch and keeps its value, unless it failed or the kind was invalidated since the given generation
+func (c *Cache) store(key entryKey, generation uint64, value any, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats[key.kind]
+	stats.Fetches++
+	c.stats[key.kind] = stats
+	if ttl := c.ttls[key.kind]; err == nil && ttl > 0 && c.generations[key.kind] == generation {
+		c.entries[key] = entry{value: value, expires: c.now().Add(ttl)}
+	}
+}
+
+// cached returns the entry of the key, fetching it on a miss. Errors are not cached.
+//
+// Concurrent misses share a fetch, which is not canceled with the context of the caller that started it,
+// so that the others still get its result. Each caller waits until its own context is done.
+// A miss after an invalidation does not join a fetch started before it, which may miss the write.
+func cached[T any](ctx context.Context, c *Cache, key entryKey, fetch func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	value, ok, generation := c.lookup(key)
+	if ok {
+		return value.(T), nil
+	}
+	ch := c.flight.DoChan(string(key.kind)+"/"+key.id+"/"+strconv.FormatUint(generation, 10), func() (any, error) {
+		value, err := fetch(context.WithoutCancel(ctx))
+		c.store(key, generation, value, err)
+		return value, err
+	})
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case result := <-ch:
+		if result.Err != nil {
+			return zero, result.Err
+		}
+		return result.Val.(T), nil
+	}
+}
+
+// invalidating drops the entries of the kind after a write, whatever its outcome
+func invalidating[T any](c *Cache, kind Kind, value T, err error) (T, error) {
+	c.Invalidate(kind)
+	return value, err
+}
@@ -0,0 +1,268 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationcache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationcache"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_TTL(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	now := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	cache := simplenotificationcache.New(
+		simplenotificationcache.WithClock(func() time.Time { return now }),
+		simplenotificationcache.WithTTL(simplenotificationcache.KindSource, 10*time.Minute),
+	)
+	var lists, sources int
+	mock := &simplenotificationmock.RoutingAPI{
+		ListFunc: func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+			lists++
+			return &v1.ListCommonServiceItemsResponse{Count: v1.NewOptInt(lists)}, nil
+		},
+		ListSourceFunc: func(ctx context.Context) (*v1.ListSourcesResponse, error) {
+			sources++
+			return &v1.ListSourcesResponse{}, nil
+		},
+	}
+	routingAPI := cache.Routing(mock)
+
+	for range 3 {
+		res, err := routingAPI.List(ctx)
+		assert.NoError(err)
+		assert.Equal(1, res.Count.Value)
+		_, err = routingAPI.ListSource(ctx)
+		assert.NoError(err)
+	}
+	now = now.Add(simplenotificationcache.DefaultTTL)
+	res, err := routingAPI.List(ctx)
+	assert.NoError(err)
+	assert.Equal(2, res.Count.Value)
+	_, err = routingAPI.ListSource(ctx)
+	assert.NoError(err)
+	assert.Equal(1, sources)
+
+	stats := cache.Stats()
+	assert.Equal(simplenotificationcache.Stats{Hits: 2, Misses: 2, Fetches: 2}, stats[simplenotificationcache.KindRouting])
+	assert.Equal(simplenotificationcache.Stats{Hits: 3, Misses: 1, Fetches: 1}, stats[simplenotificationcache.KindSource])
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	cache := simplenotificationcache.New()
+	var reads int
+	mock := &simplenotificationmock.GroupAPI{
+		ReadFunc: func(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+			reads++
+			return &v1.GetCommonServiceItemOK{CommonServiceItem: v1.CommonServiceItem{ID: id}}, nil
+		},
+		DeleteFunc: func(ctx context.Context, id string) error {
+			return errors.New("delete failed")
+		},
+		SendMessageFunc: func(ctx context.Context, id string, request v1.SendNotificationMessageRequest) (*v1.SendNotificationMessageResponse, error) {
+			return &v1.SendNotificationMessageResponse{}, nil
+		},
+	}
+	groupAPI := cache.Group(mock)
+	read := func() {
+		t.Helper()
+		res, err := groupAPI.Read(ctx, "123")
+		assert.NoError(err)
+		assert.Equal("123", res.CommonServiceItem.ID)
+	}
+
+	read()
+	read()
+	assert.Equal(1, reads)
+
+	// a write invalidates the kind even when it fails, since it may have been applied
+	assert.Error(groupAPI.Delete(ctx, "123"))
+	read()
+	assert.Equal(2, reads)
+
+	// sending a message does not change the groups
+	_, err := groupAPI.SendMessage(ctx, "123", v1.SendNotificationMessageRequest{Message: "hello"})
+	assert.NoError(err)
+	read()
+	assert.Equal(2, reads)
+
+	cache.Invalidate(simplenotificationcache.KindDestination)
+	read()
+	assert.Equal(2, reads)
+	cache.Invalidate()
+	read()
+	assert.Equal(3, reads)
+}
+
+func TestCache_SingleFlight(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	cache := simplenotificationcache.New()
+	release := make(chan struct{})
+	var calls atomic.Int32
+	mock := &simplenotificationmock.DestinationAPI{
+		ListFunc: func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+			if calls.Add(1) == 1 {
+				<-release
+				return nil, errors.New("unavailable")
+			}
+			return &v1.ListCommonServiceItemsResponse{}, nil
+		},
+	}
+	destinationAPI := cache.Destination(mock)
+
+	const callers = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Go(func() {
+			_, err := destinationAPI.List(ctx)
+			errs <- err
+		})
+	}
+	assert.Eventually(func() bool {
+		return cache.Stats()[simplenotificationcache.KindDestination].Misses == callers
+	}, time.Second, time.Millisecond)
+	// let the last caller join the request in flight after counting its miss
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.ErrorContains(err, "unavailable")
+	}
+	assert.Equal(int32(1), calls.Load())
+
+	// errors are not cached
+	_, err := destinationAPI.List(ctx)
+	assert.NoError(err)
+	_, err = destinationAPI.List(ctx)
+	assert.NoError(err)
+	assert.Equal(int32(2), calls.Load())
+	assert.Equal(simplenotificationcache.Stats{Hits: 1, Misses: callers + 1, Fetches: 2}, cache.Stats()[simplenotificationcache.KindDestination])
+}
+
+func TestCache_SingleFlightCanceled(t *testing.T) {
+	assert := require.New(t)
+	cache := simplenotificationcache.New()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	mock := &simplenotificationmock.DestinationAPI{
+		ListFunc: func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+			calls.Add(1)
+			close(started)
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			return &v1.ListCommonServiceItemsResponse{CommonServiceItems: []v1.CommonServiceItem{{ID: "1"}}}, nil
+		},
+	}
+	destinationAPI := cache.Destination(mock)
+
+	// the caller starting the fetch gives up, the one joining it still gets the result
+	first, cancel := context.WithCancel(t.Context())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := destinationAPI.List(first)
+		firstErr <- err
+	}()
+	<-started
+	var second *v1.ListCommonServiceItemsResponse
+	secondErr := make(chan error, 1)
+	go func() {
+		var err error
+		second, err = destinationAPI.List(t.Context())
+		secondErr <- err
+	}()
+	assert.Eventually(func() bool {
+		return cache.Stats()[simplenotificationcache.KindDestination].Misses == 2
+	}, time.Second, time.Millisecond)
+	// let the second caller join the request in flight after counting its miss
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(<-firstErr, context.Canceled)
+	close(release)
+	assert.NoError(<-secondErr)
+	assert.Len(second.CommonServiceItems, 1)
+	assert.Equal(int32(1), calls.Load())
+
+	// the result of the fetch is cached
+	res, err := destinationAPI.List(t.Context())
+	assert.NoError(err)
+	assert.Len(res.CommonServiceItems, 1)
+	assert.Equal(int32(1), calls.Load())
+}
+
+func TestCache_SingleFlightAfterWrite(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	cache := simplenotificationcache.New()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	mock := &simplenotificationmock.DestinationAPI{
+		ListFunc: func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+			if calls.Add(1) == 1 {
+				close(started)
+				<-release
+				return &v1.ListCommonServiceItemsResponse{}, nil
+			}
+			return &v1.ListCommonServiceItemsResponse{CommonServiceItems: []v1.CommonServiceItem{{ID: "1"}}}, nil
+		},
+		CreateFunc: func(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+			return &v1.CreateCommonServiceItemCreated{CommonServiceItem: v1.CommonServiceItem{ID: "1"}}, nil
+		},
+	}
+	destinationAPI := cache.Destination(mock)
+
+	// a list started before the create does not serve the list after it
+	stale := make(chan *v1.ListCommonServiceItemsResponse, 1)
+	go func() {
+		res, _ := destinationAPI.List(ctx)
+		stale <- res
+	}()
+	<-started
+	_, err := destinationAPI.Create(ctx, v1.PostCommonServiceItemRequest{})
+	assert.NoError(err)
+	// joining the blocked list would wait until the timeout
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	res, err := destinationAPI.List(waitCtx)
+	assert.NoError(err)
+	assert.Len(res.CommonServiceItems, 1)
+	close(release)
+	assert.Empty((<-stale).CommonServiceItems)
+	assert.Equal(int32(2), calls.Load())
+
+	// the stale result is not cached over the new one
+	res, err = destinationAPI.List(ctx)
+	assert.NoError(err)
+	assert.Len(res.CommonServiceItems, 1)
+	assert.Equal(int32(2), calls.Load())
+}
@@ -0,0 +1,244 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simplenotificationexport writes notification histories as CSV or JSON Lines, a row per delivery.
+//
+// An Exporter writes each history as soon as it is given, so that large archives stream through it:
+//
+//	exporter, err := simplenotificationexport.NewExporter(os.Stdout, simplenotificationexport.FormatCSV,
+//		simplenotificationexport.WithEnricher(enricher))
+//	if err != nil {
+//		return err
+//	}
+//	for _, history := range histories.NotificationHistories {
+//		if err := exporter.Write(history); err != nil {
+//			return err
+//		}
+//	}
+//	return exporter.Flush()
+package simplenotificationexport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// Format is an output format of an Exporter
+type Format string
+
+const (
+	FormatCSV Format = "csv"
+	// FormatJSONL writes a JSON object per line
+	FormatJSONL Format = "jsonl"
+	// FormatNDJSON is the same as FormatJSONL, under the name some tools expect
+	FormatNDJSON Format = "ndjson"
+)
+
+// Formats are the supported formats
+var Formats = []Format{FormatCSV, FormatJSONL, FormatNDJSON}
+
+// Option configures an Exporter
+type Option func(*config)
+
+type config struct {
+	location *time.Location
+	enricher *simplenotification.Enricher
+}
+
+// WithLocation sets the time zone of the timestamps, simplenotification.JST by default
+func WithLocation(loc *time.Location) Option {
+	return func(c *config) { c.location = loc }
+}
+
+// WithEnricher fills the names of the source, groups and destinations and the types of the destinations
+// resolved by the enricher. Without it they are empty.
+func WithEnricher(enricher *simplenotification.Enricher) Option {
+	return func(c *config) { c.enricher = enricher }
+}
+
+// Row is a delivery of a notification to a destination.
+// A history without a delivery is a row with the fields of the history only.
+type Row struct {
+	RequestID       string    `json:"request_id"`
+	SourceID        string    `json:"source_id"`
+	SourceName      string    `json:"source_name"`
+	ReceivedAt      time.Time `json:"received_at"`
+	Title           string    `json:"title"`
+	Body            string    `json:"body"`
+	GroupID         string    `json:"group_id"`
+	GroupName       string    `json:"group_name"`
+	DestinationID   string    `json:"destination_id"`
+	DestinationName string    `json:"destination_name"`
+	DestinationType string    `json:"destination_type"`
+	StatusID        string    `json:"status_id"`
+	Status          string    `json:"status"`
+	ErrorInfo       string    `json:"error_info"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+var csvHeader = []string{
+	"request_id", "source_id", "source_name", "received_at", "title", "body",
+	"group_id", "group_name", "destination_id", "destination_name", "destination_type",
+	"status_id", "status", "error_info", "created_at", "updated_at",
+}
+
+func (r *Row) csvRecord() []string {
+	return []string{
+		r.RequestID, r.SourceID, r.SourceName, formatTime(r.ReceivedAt), r.Title, r.Body,
+		r.GroupID, r.GroupName, r.DestinationID, r.DestinationName, r.DestinationType,
+		r.StatusID, r.Status, r.ErrorInfo, formatTime(r.CreatedAt), formatTime(r.UpdatedAt),
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// StatusText returns the name of a delivery status: pending, sending, sent or failed
+func StatusText(status v1.NotificationStatusStatus) string {
+	switch status {
+	case v1.NotificationStatusStatus0:
+		return "pending"
+	case v1.NotificationStatusStatus1:
+		return "sending"
+	case v1.NotificationStatusStatus2:
+		return "sent"
+	case v1.NotificationStatusStatus9:
+		return "failed"
+	}
+	return strconv.Itoa(int(status))
+}
+
+// Exporter writes the rows of notification histories in a format
+type Exporter struct {
+	config
+	format Format
+	csv    *csv.Writer
+	json   *json.Encoder
+	header bool
+}
+
+// NewExporter creates an Exporter writing to w
+func NewExporter(w io.Writer, format Format, opts ...Option) (*Exporter, error) {
+	e := &Exporter{config: config{location: simplenotification.JST}, format: format}
+	for _, opt := range opts {
+		opt(&e.config)
+	}
+	switch format {
+	case FormatCSV:
+		e.csv = csv.NewWriter(w)
+	case FormatJSONL, FormatNDJSON:
+		e.json = json.NewEncoder(w)
+		e.json.SetEscapeHTML(false)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, one of %v", format, Formats)
+	}
+	return e, nil
+}
+
+// Rows flattens a history into its rows, a row per delivery
+func (e *Exporter) Rows(history v1.NotificationHistory) []Row {
+	base := Row{
+		RequestID:  history.RequestID,
+		SourceID:   history.SourceID,
+		ReceivedAt: e.in(history.ReceivedAt),
+		Title:      history.Message.Title,
+		Body:       history.Message.Body,
+	}
+	var deliveries []simplenotification.EnrichedStatus
+	if e.enricher != nil {
+		enriched := e.enricher.Enrich(history)
+		base.SourceName = enriched.Source.Name
+		deliveries = enriched.Deliveries
+	}
+	if len(history.Statuses) == 0 {
+		return []Row{base}
+	}
+	rows := make([]Row, len(history.Statuses))
+	for i, status := range history.Statuses {
+		row := base
+		row.GroupID = status.GroupID
+		row.DestinationID = status.DestinationID
+		row.StatusID = status.ID
+		row.Status = StatusText(status.Status)
+		row.ErrorInfo = status.ErrorInfo
+		row.CreatedAt = e.in(status.CreatedAt)
+		row.UpdatedAt = e.in(status.UpdatedAt)
+		if deliveries != nil {
+			row.GroupName = deliveries[i].Group.Name
+			row.DestinationName = deliveries[i].Destination.Name
+			row.DestinationType = string(deliveries[i].Destination.Type)
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+func (e *Exporter) in(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.In(e.location)
+}
+
+// Write writes the rows of a history. The CSV header is written before the first row.
+func (e *Exporter) Write(history v1.NotificationHistory) error {
+	for _, row := range e.Rows(history) {
+		if err := e.writeRow(&row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) writeRow(row *Row) error {
+	if e.json != nil {
+		return e.json.Encode(row)
+	}
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.csv.Write(row.csvRecord())
+}
+
+func (e *Exporter) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	return e.csv.Write(csvHeader)
+}
+
+// Flush writes the buffered rows, and the CSV header when no row was written
+func (e *Exporter) Flush() error {
+	if e.csv == nil {
+		return nil
+	}
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.csv.Flush()
+	return e.csv.Error()
+}
@@ -0,0 +1,99 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationexport_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationexport"
+	"github.com/stretchr/testify/require"
+)
+
+var received = time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+func sampleHistory() v1.NotificationHistory {
+	return v1.NotificationHistory{
+		RequestID:  "r1",
+		SourceID:   "1",
+		ReceivedAt: received,
+		Message:    v1.NotificationMessage{Title: "alert", Body: "disk full, 95%"},
+		Statuses: []v1.NotificationStatus{
+			{ID: "s1", GroupID: "g1", DestinationID: "d1", Status: v1.NotificationStatusStatus2, CreatedAt: received, UpdatedAt: received.Add(time.Second)},
+			{ID: "s2", GroupID: "g1", DestinationID: "d2", Status: v1.NotificationStatusStatus9, ErrorInfo: "timeout", CreatedAt: received, UpdatedAt: received.Add(time.Minute)},
+		},
+	}
+}
+
+func sampleEnricher() *simplenotification.Enricher {
+	dest := v1.CommonServiceItem{ID: "d1", Name: "oncall", Provider: v1.CommonServiceItemProvider{Class: v1.CommonServiceItemProviderClassSaknoticedestination}}
+	dest.Settings.CommonServiceItemDestinationSettings.Type = v1.CommonServiceItemDestinationSettingsTypeEmail
+	group := v1.CommonServiceItem{ID: "g1", Name: "ops", Provider: v1.CommonServiceItemProvider{Class: v1.CommonServiceItemProviderClassSaknoticegroup}}
+	inv := simplenotification.NewInventory([]v1.CommonServiceItem{dest}, []v1.CommonServiceItem{group}, nil)
+	return simplenotification.NewEnricher(inv, []v1.ListSourcesResponseSourcesItem{{ID: "1", Name: "Monitoring"}})
+}
+
+func TestExporter_CSV(t *testing.T) {
+	assert := require.New(t)
+	var buf bytes.Buffer
+	exporter, err := simplenotificationexport.NewExporter(&buf, simplenotificationexport.FormatCSV,
+		simplenotificationexport.WithEnricher(sampleEnricher()))
+	assert.NoError(err)
+	assert.NoError(exporter.Write(sampleHistory()))
+	assert.NoError(exporter.Write(v1.NotificationHistory{RequestID: "r2", SourceID: "2", ReceivedAt: received}))
+	assert.NoError(exporter.Flush())
+
+	assert.Equal(`request_id,source_id,source_name,received_at,title,body,group_id,group_name,destination_id,destination_name,destination_type,status_id,status,error_info,created_at,updated_at
+r1,1,Monitoring,2026-04-01T09:00:00+09:00,alert,"disk full, 95%",g1,ops,d1,oncall,email,s1,sent,,2026-04-01T09:00:00+09:00,2026-04-01T09:00:01+09:00
+r1,1,Monitoring,2026-04-01T09:00:00+09:00,alert,"disk full, 95%",g1,ops,d2,,,s2,failed,timeout,2026-04-01T09:00:00+09:00,2026-04-01T09:01:00+09:00
+r2,2,,2026-04-01T09:00:00+09:00,,,,,,,,,,,,
+`, buf.String())
+
+	// an empty export still has the header
+	buf.Reset()
+	exporter, err = simplenotificationexport.NewExporter(&buf, simplenotificationexport.FormatCSV)
+	assert.NoError(err)
+	assert.NoError(exporter.Flush())
+	assert.True(strings.HasPrefix(buf.String(), "request_id,"))
+}
+
+func TestExporter_JSONL(t *testing.T) {
+	assert := require.New(t)
+	for _, format := range []simplenotificationexport.Format{simplenotificationexport.FormatJSONL, simplenotificationexport.FormatNDJSON} {
+		var buf bytes.Buffer
+		exporter, err := simplenotificationexport.NewExporter(&buf, format, simplenotificationexport.WithLocation(time.UTC))
+		assert.NoError(err)
+		assert.NoError(exporter.Write(sampleHistory()))
+		assert.NoError(exporter.Flush())
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		assert.Len(lines, 2)
+		var row simplenotificationexport.Row
+		assert.NoError(json.Unmarshal([]byte(lines[1]), &row))
+		assert.Equal("failed", row.Status)
+		assert.Equal("timeout", row.ErrorInfo)
+		assert.Empty(row.GroupName)
+		assert.Empty(row.SourceName)
+		assert.Contains(lines[1], `"updated_at":"2026-04-01T00:01:00Z"`)
+	}
+
+	_, err := simplenotificationexport.NewExporter(&bytes.Buffer{}, "xml")
+	assert.ErrorContains(err, `unsupported format "xml"`)
+}
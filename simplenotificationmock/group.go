@@ -0,0 +1,103 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationmock
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+var _ simplenotification.GroupAPI = (*GroupAPI)(nil)
+
+// GroupAPI is a test double for simplenotification.GroupAPI
+type GroupAPI struct {
+	recorder
+
+	ListFunc        func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error)
+	CreateFunc      func(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error)
+	ReadFunc        func(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error)
+	UpdateFunc      func(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error)
+	DeleteFunc      func(ctx context.Context, id string) error
+	SendMessageFunc func(ctx context.Context, id string, request v1.SendNotificationMessageRequest) (*v1.SendNotificationMessageResponse, error)
+}
+
+func (m *GroupAPI) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+	m.record("List")
+	if m.ListFunc == nil {
+		return nil, notStubbed("GroupAPI", "List")
+	}
+	return m.ListFunc(ctx)
+}
+
+func (m *GroupAPI) Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+	m.record("Create", request)
+	if m.CreateFunc == nil {
+		return nil, notStubbed("GroupAPI", "Create")
+	}
+	return m.CreateFunc(ctx, request)
+}
+
+func (m *GroupAPI) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+	m.record("Read", id)
+	if m.ReadFunc == nil {
+		return nil, notStubbed("GroupAPI", "Read")
+	}
+	return m.ReadFunc(ctx, id)
+}
+
+func (m *GroupAPI) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
+	m.record("Update", id, request)
+	if m.UpdateFunc == nil {
+		return nil, notStubbed("GroupAPI", "Update")
+	}
+	return m.UpdateFunc(ctx, id, request)
+}
+
+func (m *GroupAPI) Delete(ctx context.Context, id string) error {
+	m.record("Delete", id)
+	if m.DeleteFunc == nil {
+		return notStubbed("GroupAPI", "Delete")
+	}
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *GroupAPI) SendMessage(ctx context.Context, id string, request v1.SendNotificationMessageRequest) (*v1.SendNotificationMessageResponse, error) {
+	m.record("SendMessage", id, request)
+	if m.SendMessageFunc == nil {
+		return nil, notStubbed("GroupAPI", "SendMessage")
+	}
+	return m.SendMessageFunc(ctx, id, request)
+}
+
+// AssertSendMessageCalled fails t unless SendMessage was called for groupID with a message containing substr
+func (m *GroupAPI) AssertSendMessageCalled(t testing.TB, groupID, substr string) {
+	t.Helper()
+	var messages []string
+	for _, c := range m.CallsTo("SendMessage") {
+		if c.Args[0] != groupID {
+			continue
+		}
+		msg := c.Args[1].(v1.SendNotificationMessageRequest).Message
+		if strings.Contains(msg, substr) {
+			return
+		}
+		messages = append(messages, msg)
+	}
+	t.Errorf("expected SendMessage to group %s with a message containing %q, got messages %q", groupID, substr, messages)
+}
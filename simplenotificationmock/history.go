@@ -0,0 +1,48 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationmock
+
+import (
+	"context"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+var _ simplenotification.HistoryAPI = (*HistoryAPI)(nil)
+
+// HistoryAPI is a test double for simplenotification.HistoryAPI
+type HistoryAPI struct {
+	recorder
+
+	ListFunc func(ctx context.Context) (*v1.ListSimpleNotificationHistoriesResponse, error)
+	ReadFunc func(ctx context.Context, id string) (*v1.GetSimpleNotificationHistoryResponse, error)
+}
+
+func (m *HistoryAPI) List(ctx context.Context) (*v1.ListSimpleNotificationHistoriesResponse, error) {
+	m.record("List")
+	if m.ListFunc == nil {
+		return nil, notStubbed("HistoryAPI", "List")
+	}
+	return m.ListFunc(ctx)
+}
+
+func (m *HistoryAPI) Read(ctx context.Context, id string) (*v1.GetSimpleNotificationHistoryResponse, error) {
+	m.record("Read", id)
+	if m.ReadFunc == nil {
+		return nil, notStubbed("HistoryAPI", "Read")
+	}
+	return m.ReadFunc(ctx, id)
+}
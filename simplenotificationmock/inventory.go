@@ -0,0 +1,50 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationmock
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+)
+
+var _ simplenotification.InventoryAPI = (*InventoryAPI)(nil)
+
+// InventoryAPI is a test double for simplenotification.InventoryAPI
+type InventoryAPI struct {
+	recorder
+
+	InventoryFunc func(ctx context.Context) (*simplenotification.Inventory, error)
+	WatchFunc     func(ctx context.Context, interval time.Duration, opts ...simplenotification.WatchOption) iter.Seq2[*simplenotification.WatchEvent, error]
+}
+
+func (m *InventoryAPI) Inventory(ctx context.Context) (*simplenotification.Inventory, error) {
+	m.record("Inventory")
+	if m.InventoryFunc == nil {
+		return nil, notStubbed("InventoryAPI", "Inventory")
+	}
+	return m.InventoryFunc(ctx)
+}
+
+// Watch calls WatchFunc, or polls the stubbed Inventory without it
+func (m *InventoryAPI) Watch(ctx context.Context, interval time.Duration, opts ...simplenotification.WatchOption) iter.Seq2[*simplenotification.WatchEvent, error] {
+	m.record("Watch", interval)
+	if m.WatchFunc == nil {
+		return simplenotification.WatchInventory(ctx, m, interval, opts...)
+	}
+	return m.WatchFunc(ctx, interval, opts...)
+}
@@ -0,0 +1,99 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simplenotificationmock provides test doubles for the API interfaces of simplenotification.
+//
+// Each double records its calls and delegates to an optional per-method stub function,
+// e.g. GroupAPI.SendMessageFunc. A method without stub returns an error so that
+// unexpected calls do not pass silently.
+package simplenotificationmock
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// Call is a recorded method call. Args holds the arguments except the context.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls in order
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// CallsTo returns the recorded calls of method in order
+func (r *recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ret []Call
+	for _, c := range r.calls {
+		if c.Method == method {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Reset forgets all recorded calls. Stub functions are kept.
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// AssertCalled fails t unless method was called at least once
+func (r *recorder) AssertCalled(t testing.TB, method string) {
+	t.Helper()
+	if len(r.CallsTo(method)) == 0 {
+		t.Errorf("expected %s to be called, but it was not", method)
+	}
+}
+
+// AssertNotCalled fails t if method was called
+func (r *recorder) AssertNotCalled(t testing.TB, method string) {
+	t.Helper()
+	if calls := r.CallsTo(method); len(calls) > 0 {
+		t.Errorf("expected %s not to be called, but it was called %d time(s): %v", method, len(calls), calls)
+	}
+}
+
+// AssertNumberOfCalls fails t unless method was called exactly n times
+func (r *recorder) AssertNumberOfCalls(t testing.TB, method string, n int) {
+	t.Helper()
+	if calls := r.CallsTo(method); len(calls) != n {
+		t.Errorf("expected %s to be called %d time(s), but it was called %d time(s)", method, n, len(calls))
+	}
+}
+
+func notStubbed(api, method string) error {
+	return fmt.Errorf("simplenotificationmock: %s.%s is not stubbed", api, method)
+}
@@ -0,0 +1,111 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationmock_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationmock"
+	"github.com/stretchr/testify/require"
+)
+
+// spyT records failures instead of failing the running test
+type spyT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *spyT) Helper() {}
+func (t *spyT) Errorf(format string, args ...any) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func TestGroupAPI_SendMessage(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+
+	mock := &simplenotificationmock.GroupAPI{
+		SendMessageFunc: func(ctx context.Context, id string, request v1.SendNotificationMessageRequest) (*v1.SendNotificationMessageResponse, error) {
+			return &v1.SendNotificationMessageResponse{IsOk: true}, nil
+		},
+	}
+	res, err := mock.SendMessage(ctx, "123456789012", v1.SendNotificationMessageRequest{Message: "deploy failed: api"})
+	assert.NoError(err)
+	assert.True(res.IsOk)
+
+	assert.Equal([]simplenotificationmock.Call{
+		{Method: "SendMessage", Args: []any{"123456789012", v1.SendNotificationMessageRequest{Message: "deploy failed: api"}}},
+	}, mock.Calls())
+
+	spy := &spyT{TB: t}
+	mock.AssertSendMessageCalled(spy, "123456789012", "deploy failed")
+	mock.AssertCalled(spy, "SendMessage")
+	mock.AssertNumberOfCalls(spy, "SendMessage", 1)
+	mock.AssertNotCalled(spy, "Delete")
+	assert.Empty(spy.failures)
+
+	mock.AssertSendMessageCalled(spy, "123456789012", "succeeded")
+	mock.AssertSendMessageCalled(spy, "999999999999", "deploy failed")
+	mock.AssertNotCalled(spy, "SendMessage")
+	assert.Len(spy.failures, 3)
+
+	mock.Reset()
+	assert.Empty(mock.Calls())
+}
+
+func TestDestinationAPI_NotStubbed(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+
+	mock := &simplenotificationmock.DestinationAPI{}
+	_, err := mock.Read(ctx, "123456789012")
+	assert.EqualError(err, "simplenotificationmock: DestinationAPI.Read is not stubbed")
+	assert.Error(mock.Delete(ctx, "123456789012"))
+
+	assert.Len(mock.CallsTo("Read"), 1)
+	assert.Equal([]any{"123456789012"}, mock.CallsTo("Delete")[0].Args)
+}
+
+func TestRoutingAPI_ListSource(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+
+	sources := &v1.ListSourcesResponse{Sources: []v1.ListSourcesResponseSourcesItem{{ID: "1", Name: "SimpleMonitor"}}}
+	mock := &simplenotificationmock.RoutingAPI{
+		ListSourceFunc: func(ctx context.Context) (*v1.ListSourcesResponse, error) { return sources, nil },
+	}
+	res, err := mock.ListSource(ctx)
+	assert.NoError(err)
+	assert.Equal(sources, res)
+	assert.Len(mock.CallsTo("ListSource"), 1)
+}
+
+func TestHistoryAPI_Read(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+
+	mock := &simplenotificationmock.HistoryAPI{
+		ReadFunc: func(ctx context.Context, id string) (*v1.GetSimpleNotificationHistoryResponse, error) {
+			return &v1.GetSimpleNotificationHistoryResponse{NotificationHistory: v1.NotificationHistory{RequestID: id}}, nil
+		},
+	}
+	res, err := mock.Read(ctx, "12345")
+	assert.NoError(err)
+	assert.Equal("12345", res.NotificationHistory.RequestID)
+	assert.Equal([]any{"12345"}, mock.CallsTo("Read")[0].Args)
+}
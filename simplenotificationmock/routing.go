@@ -0,0 +1,93 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationmock
+
+import (
+	"context"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+var _ simplenotification.RoutingAPI = (*RoutingAPI)(nil)
+
+// RoutingAPI is a test double for simplenotification.RoutingAPI
+type RoutingAPI struct {
+	recorder
+
+	ListFunc       func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error)
+	CreateFunc     func(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error)
+	ReadFunc       func(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error)
+	UpdateFunc     func(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error)
+	DeleteFunc     func(ctx context.Context, id string) error
+	ReorderFunc    func(ctx context.Context, request v1.PutCommonServiceItemRoutingReorderRequest) (*v1.ReorderRoutingAccepted, error)
+	ListSourceFunc func(ctx context.Context) (*v1.ListSourcesResponse, error)
+}
+
+func (m *RoutingAPI) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+	m.record("List")
+	if m.ListFunc == nil {
+		return nil, notStubbed("RoutingAPI", "List")
+	}
+	return m.ListFunc(ctx)
+}
+
+func (m *RoutingAPI) Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+	m.record("Create", request)
+	if m.CreateFunc == nil {
+		return nil, notStubbed("RoutingAPI", "Create")
+	}
+	return m.CreateFunc(ctx, request)
+}
+
+func (m *RoutingAPI) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+	m.record("Read", id)
+	if m.ReadFunc == nil {
+		return nil, notStubbed("RoutingAPI", "Read")
+	}
+	return m.ReadFunc(ctx, id)
+}
+
+func (m *RoutingAPI) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
+	m.record("Update", id, request)
+	if m.UpdateFunc == nil {
+		return nil, notStubbed("RoutingAPI", "Update")
+	}
+	return m.UpdateFunc(ctx, id, request)
+}
+
+func (m *RoutingAPI) Delete(ctx context.Context, id string) error {
+	m.record("Delete", id)
+	if m.DeleteFunc == nil {
+		return notStubbed("RoutingAPI", "Delete")
+	}
+	return m.DeleteFunc(ctx, id)
+}
+
+func (m *RoutingAPI) Reorder(ctx context.Context, request v1.PutCommonServiceItemRoutingReorderRequest) (*v1.ReorderRoutingAccepted, error) {
+	m.record("Reorder", request)
+	if m.ReorderFunc == nil {
+		return nil, notStubbed("RoutingAPI", "Reorder")
+	}
+	return m.ReorderFunc(ctx, request)
+}
+
+func (m *RoutingAPI) ListSource(ctx context.Context) (*v1.ListSourcesResponse, error) {
+	m.record("ListSource")
+	if m.ListSourceFunc == nil {
+		return nil, notStubbed("RoutingAPI", "ListSource")
+	}
+	return m.ListSourceFunc(ctx)
+}
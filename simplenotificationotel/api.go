@@ -0,0 +1,225 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationotel
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+func createdID(res *v1.CreateCommonServiceItemCreated) string {
+	return res.CommonServiceItem.ID
+}
+
+var _ simplenotification.DestinationAPI = (*destinationAPI)(nil)
+
+type destinationAPI struct {
+	api simplenotification.DestinationAPI
+	in  *instrumentation
+}
+
+// Destination instruments a DestinationAPI
+func Destination(api simplenotification.DestinationAPI, opts ...Option) simplenotification.DestinationAPI {
+	return &destinationAPI{api: api, in: newInstrumentation(opts)}
+}
+
+func (a *destinationAPI) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+	return do(ctx, a.in, call{method: "Destination.List", class: v1.CommonServiceItemProviderClassSaknoticedestination}, func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+		return a.api.List(ctx)
+	}, nil)
+}
+
+func (a *destinationAPI) Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+	return do(ctx, a.in, call{method: "Destination.Create", class: v1.CommonServiceItemProviderClassSaknoticedestination}, func(ctx context.Context) (*v1.CreateCommonServiceItemCreated, error) {
+		return a.api.Create(ctx, request)
+	}, createdID)
+}
+
+func (a *destinationAPI) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+	return do(ctx, a.in, call{method: "Destination.Read", class: v1.CommonServiceItemProviderClassSaknoticedestination, id: id}, func(ctx context.Context) (*v1.GetCommonServiceItemOK, error) {
+		return a.api.Read(ctx, id)
+	}, nil)
+}
+
+func (a *destinationAPI) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
+	return do(ctx, a.in, call{method: "Destination.Update", class: v1.CommonServiceItemProviderClassSaknoticedestination, id: id}, func(ctx context.Context) (*v1.UpdateCommonServiceItemOK, error) {
+		return a.api.Update(ctx, id, request)
+	}, nil)
+}
+
+func (a *destinationAPI) Delete(ctx context.Context, id string) error {
+	return doErr(ctx, a.in, call{method: "Destination.Delete", class: v1.CommonServiceItemProviderClassSaknoticedestination, id: id}, func(ctx context.Context) error {
+		return a.api.Delete(ctx, id)
+	})
+}
+
+func (a *destinationAPI) GetStatus(ctx context.Context, id string) (*v1.GetCommonServiceItemStatusResponse, error) {
+	return do(ctx, a.in, call{method: "Destination.GetStatus", class: v1.CommonServiceItemProviderClassSaknoticedestination, id: id}, func(ctx context.Context) (*v1.GetCommonServiceItemStatusResponse, error) {
+		return a.api.GetStatus(ctx, id)
+	}, nil)
+}
+
+var _ simplenotification.GroupAPI = (*groupAPI)(nil)
+
+type groupAPI struct {
+	api simplenotification.GroupAPI
+	in  *instrumentation
+}
+
+// Group instruments a GroupAPI
+func Group(api simplenotification.GroupAPI, opts ...Option) simplenotification.GroupAPI {
+	return &groupAPI{api: api, in: newInstrumentation(opts)}
+}
+
+func (a *groupAPI) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+	return do(ctx, a.in, call{method: "Group.List", class: v1.CommonServiceItemProviderClassSaknoticegroup}, func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+		return a.api.List(ctx)
+	}, nil)
+}
+
+func (a *groupAPI) Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+	return do(ctx, a.in, call{method: "Group.Create", class: v1.CommonServiceItemProviderClassSaknoticegroup}, func(ctx context.Context) (*v1.CreateCommonServiceItemCreated, error) {
+		return a.api.Create(ctx, request)
+	}, createdID)
+}
+
+func (a *groupAPI) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+	return do(ctx, a.in, call{method: "Group.Read", class: v1.CommonServiceItemProviderClassSaknoticegroup, id: id}, func(ctx context.Context) (*v1.GetCommonServiceItemOK, error) {
+		return a.api.Read(ctx, id)
+	}, nil)
+}
+
+func (a *groupAPI) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
+	return do(ctx, a.in, call{method: "Group.Update", class: v1.CommonServiceItemProviderClassSaknoticegroup, id: id}, func(ctx context.Context) (*v1.UpdateCommonServiceItemOK, error) {
+		return a.api.Update(ctx, id, request)
+	}, nil)
+}
+
+func (a *groupAPI) Delete(ctx context.Context, id string) error {
+	return doErr(ctx, a.in, call{method: "Group.Delete", class: v1.CommonServiceItemProviderClassSaknoticegroup, id: id}, func(ctx context.Context) error {
+		return a.api.Delete(ctx, id)
+	})
+}
+
+func (a *groupAPI) SendMessage(ctx context.Context, id string, request v1.SendNotificationMessageRequest) (*v1.SendNotificationMessageResponse, error) {
+	return do(ctx, a.in, call{method: "Group.SendMessage", class: v1.CommonServiceItemProviderClassSaknoticegroup, id: id}, func(ctx context.Context) (*v1.SendNotificationMessageResponse, error) {
+		return a.api.SendMessage(ctx, id, request)
+	}, nil)
+}
+
+var _ simplenotification.RoutingAPI = (*routingAPI)(nil)
+
+type routingAPI struct {
+	api simplenotification.RoutingAPI
+	in  *instrumentation
+}
+
+// Routing instruments a RoutingAPI
+func Routing(api simplenotification.RoutingAPI, opts ...Option) simplenotification.RoutingAPI {
+	return &routingAPI{api: api, in: newInstrumentation(opts)}
+}
+
+func (a *routingAPI) List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+	return do(ctx, a.in, call{method: "Routing.List", class: v1.CommonServiceItemProviderClassSaknoticerouting}, func(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error) {
+		return a.api.List(ctx)
+	}, nil)
+}
+
+func (a *routingAPI) Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error) {
+	return do(ctx, a.in, call{method: "Routing.Create", class: v1.CommonServiceItemProviderClassSaknoticerouting}, func(ctx context.Context) (*v1.CreateCommonServiceItemCreated, error) {
+		return a.api.Create(ctx, request)
+	}, createdID)
+}
+
+func (a *routingAPI) Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error) {
+	return do(ctx, a.in, call{method: "Routing.Read", class: v1.CommonServiceItemProviderClassSaknoticerouting, id: id}, func(ctx context.Context) (*v1.GetCommonServiceItemOK, error) {
+		return a.api.Read(ctx, id)
+	}, nil)
+}
+
+func (a *routingAPI) Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error) {
+	return do(ctx, a.in, call{method: "Routing.Update", class: v1.CommonServiceItemProviderClassSaknoticerouting, id: id}, func(ctx context.Context) (*v1.UpdateCommonServiceItemOK, error) {
+		return a.api.Update(ctx, id, request)
+	}, nil)
+}
+
+func (a *routingAPI) Delete(ctx context.Context, id string) error {
+	return doErr(ctx, a.in, call{method: "Routing.Delete", class: v1.CommonServiceItemProviderClassSaknoticerouting, id: id}, func(ctx context.Context) error {
+		return a.api.Delete(ctx, id)
+	})
+}
+
+func (a *routingAPI) Reorder(ctx context.Context, request v1.PutCommonServiceItemRoutingReorderRequest) (*v1.ReorderRoutingAccepted, error) {
+	return do(ctx, a.in, call{method: "Routing.Reorder", class: v1.CommonServiceItemProviderClassSaknoticerouting}, func(ctx context.Context) (*v1.ReorderRoutingAccepted, error) {
+		return a.api.Reorder(ctx, request)
+	}, nil)
+}
+
+func (a *routingAPI) ListSource(ctx context.Context) (*v1.ListSourcesResponse, error) {
+	return do(ctx, a.in, call{method: "Routing.ListSource"}, func(ctx context.Context) (*v1.ListSourcesResponse, error) {
+		return a.api.ListSource(ctx)
+	}, nil)
+}
+
+var _ simplenotification.HistoryAPI = (*historyAPI)(nil)
+
+type historyAPI struct {
+	api simplenotification.HistoryAPI
+	in  *instrumentation
+}
+
+// History instruments a HistoryAPI
+func History(api simplenotification.HistoryAPI, opts ...Option) simplenotification.HistoryAPI {
+	return &historyAPI{api: api, in: newInstrumentation(opts)}
+}
+
+func (a *historyAPI) List(ctx context.Context) (*v1.ListSimpleNotificationHistoriesResponse, error) {
+	return do(ctx, a.in, call{method: "History.List"}, func(ctx context.Context) (*v1.ListSimpleNotificationHistoriesResponse, error) {
+		return a.api.List(ctx)
+	}, nil)
+}
+
+func (a *historyAPI) Read(ctx context.Context, id string) (*v1.GetSimpleNotificationHistoryResponse, error) {
+	return do(ctx, a.in, call{method: "History.Read", id: id}, func(ctx context.Context) (*v1.GetSimpleNotificationHistoryResponse, error) {
+		return a.api.Read(ctx, id)
+	}, nil)
+}
+
+var _ simplenotification.InventoryAPI = (*inventoryAPI)(nil)
+
+type inventoryAPI struct {
+	api simplenotification.InventoryAPI
+	in  *instrumentation
+}
+
+// Inventory instruments an InventoryAPI
+func Inventory(api simplenotification.InventoryAPI, opts ...Option) simplenotification.InventoryAPI {
+	return &inventoryAPI{api: api, in: newInstrumentation(opts)}
+}
+
+func (a *inventoryAPI) Inventory(ctx context.Context) (*simplenotification.Inventory, error) {
+	return do(ctx, a.in, call{method: "Inventory.Inventory"}, func(ctx context.Context) (*simplenotification.Inventory, error) {
+		return a.api.Inventory(ctx)
+	}, nil)
+}
+
+// Watch polls the instrumented Inventory, so that each poll has its span
+func (a *inventoryAPI) Watch(ctx context.Context, interval time.Duration, opts ...simplenotification.WatchOption) iter.Seq2[*simplenotification.WatchEvent, error] {
+	return simplenotification.WatchInventory(ctx, a, interval, opts...)
+}
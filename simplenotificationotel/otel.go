@@ -0,0 +1,188 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simplenotificationotel instruments the simple-notification API with OpenTelemetry.
+//
+// Wrap an API to get a span, a call counter and a latency histogram per method, named after the methodName
+// of the op such as "Group.SendMessage":
+//
+//	groupAPI := simplenotificationotel.Group(simplenotification.NewGroupOp(client))
+//
+// Add Middleware to the saclient.Client for a span per HTTP request, nested under the span of the call:
+//
+//	saClient.SetWith(saclient.WithMiddleware(simplenotificationotel.Middleware()))
+//
+// The package is a module of its own, so that only its importers depend on OpenTelemetry.
+package simplenotificationotel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and the meter
+const ScopeName = "github.com/sacloud/simple-notification-api-go/simplenotificationotel"
+
+// attribute keys of the spans and the metrics
+const (
+	MethodKey        = attribute.Key("simplenotification.method")
+	ResourceIDKey    = attribute.Key("simplenotification.resource.id")
+	ProviderClassKey = attribute.Key("simplenotification.provider.class")
+)
+
+// Option configures the instrumentation
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the TracerProvider. The global one is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the MeterProvider. The global one is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+type instrumentation struct {
+	tracer   trace.Tracer
+	calls    metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func newInstrumentation(opts []Option) *instrumentation {
+	c := config{tracerProvider: otel.GetTracerProvider(), meterProvider: otel.GetMeterProvider()}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	meter := c.meterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(simplenotification.Version))
+	calls, err := meter.Int64Counter("simplenotification.client.calls",
+		metric.WithDescription("Number of simple-notification API calls"), metric.WithUnit("{call}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram("simplenotification.client.duration",
+		metric.WithDescription("Duration of simple-notification API calls"), metric.WithUnit("s"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	return &instrumentation{
+		tracer:   c.tracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(simplenotification.Version)),
+		calls:    calls,
+		duration: duration,
+	}
+}
+
+// call describes an instrumented call
+type call struct {
+	method string
+	class  v1.CommonServiceItemProviderClass
+	id     string
+}
+
+func (c call) attributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{MethodKey.String(c.method)}
+	if c.class != "" {
+		attrs = append(attrs, ProviderClassKey.String(string(c.class)))
+	}
+	if c.id != "" {
+		attrs = append(attrs, ResourceIDKey.String(c.id))
+	}
+	return attrs
+}
+
+// do runs f in a span and records the metrics. resultID returns the ID of a created resource, if any.
+func do[T any](ctx context.Context, in *instrumentation, c call, f func(context.Context) (T, error), resultID func(T) string) (T, error) {
+	ctx, span := in.tracer.Start(ctx, c.method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(c.attributes()...))
+	defer span.End()
+	start := time.Now()
+
+	res, err := f(ctx)
+
+	metricAttrs := []attribute.KeyValue{MethodKey.String(c.method)}
+	if err != nil {
+		errType := "_OTHER"
+		var e *v1.ErrorStatusCode
+		if errors.As(err, &e) {
+			span.SetAttributes(semconv.HTTPResponseStatusCode(e.StatusCode))
+			errType = strconv.Itoa(e.StatusCode)
+		}
+		metricAttrs = append(metricAttrs, semconv.ErrorTypeKey.String(errType))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if resultID != nil {
+		if id := resultID(res); id != "" {
+			span.SetAttributes(ResourceIDKey.String(id))
+		}
+	}
+	set := metric.WithAttributes(metricAttrs...)
+	in.calls.Add(ctx, 1, set)
+	in.duration.Record(ctx, time.Since(start).Seconds(), set)
+	return res, err
+}
+
+// doErr is do for calls returning only an error
+func doErr(ctx context.Context, in *instrumentation, c call, f func(context.Context) error) error {
+	_, err := do(ctx, in, c, func(ctx context.Context) (struct{}, error) { return struct{}{}, f(ctx) }, nil)
+	return err
+}
+
+// Middleware returns a saclient middleware creating a span for every HTTP request
+func Middleware(opts ...Option) saclient.Middleware {
+	in := newInstrumentation(opts)
+	return func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		ctx, span := in.tracer.Start(req.Context(), req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(req.URL.String()),
+			semconv.ServerAddress(req.URL.Hostname()),
+		))
+		defer span.End()
+
+		cont, ok := pull()
+		if !ok {
+			err := errors.New("middleware not found error")
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		resp, err := cont(req.WithContext(ctx), pull)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetAttributes(semconv.ErrorTypeKey.String(strconv.Itoa(resp.StatusCode)))
+			span.SetStatus(codes.Error, resp.Status)
+		}
+		return resp, nil
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationotel_test
+
+import (
+	"testing"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationotel"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("span %q not found in %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+func TestInstrumentation(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	opts := []simplenotificationotel.Option{
+		simplenotificationotel.WithTracerProvider(tp),
+		simplenotificationotel.WithMeterProvider(mp),
+	}
+
+	srv := simplenotificationtest.NewServer()
+	t.Cleanup(srv.Close)
+	var saClient saclient.Client
+	assert.NoError(saClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}))
+	assert.NoError(saClient.SetWith(saclient.WithMiddleware(simplenotificationotel.Middleware(opts...))))
+	client, err := simplenotification.NewClientWithAPIRootURL(&saClient, srv.URL)
+	assert.NoError(err)
+
+	destinationAPI := simplenotificationotel.Destination(simplenotification.NewDestinationOp(client), opts...)
+	groupAPI := simplenotificationotel.Group(simplenotification.NewGroupOp(client), opts...)
+
+	dest, err := destinationAPI.Create(ctx, v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: "dest",
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemDestinationSettings: v1.CommonServiceItemDestinationSettings{
+					Type:  v1.CommonServiceItemDestinationSettingsTypeEmail,
+					Value: "alice@example.com",
+				},
+			},
+		},
+	})
+	assert.NoError(err)
+	destID := dest.CommonServiceItem.ID
+	group, err := groupAPI.Create(ctx, v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: "group",
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemGroupSettings: v1.CommonServiceItemGroupSettings{Destinations: []string{destID}},
+			},
+		},
+	})
+	assert.NoError(err)
+	groupID := group.CommonServiceItem.ID
+	_, err = groupAPI.SendMessage(ctx, groupID, v1.SendNotificationMessageRequest{Message: "hello"})
+	assert.NoError(err)
+	_, err = destinationAPI.Read(ctx, "123456789012")
+	assert.Error(err)
+
+	spans := exporter.GetSpans()
+
+	create := findSpan(t, spans, "Destination.Create")
+	assert.Equal(destID, spanAttr(create, simplenotificationotel.ResourceIDKey).AsString())
+	assert.Equal("saknoticedestination", spanAttr(create, simplenotificationotel.ProviderClassKey).AsString())
+
+	send := findSpan(t, spans, "Group.SendMessage")
+	assert.Equal(groupID, spanAttr(send, simplenotificationotel.ResourceIDKey).AsString())
+	assert.Equal(codes.Unset, send.Status.Code)
+	var httpSpan tracetest.SpanStub
+	for _, span := range spans {
+		if span.Parent.SpanID() == send.SpanContext.SpanID() {
+			httpSpan = span
+		}
+	}
+	assert.Equal("POST", httpSpan.Name, "HTTP span must be a child of the call span")
+	assert.Equal(int64(202), spanAttr(httpSpan, "http.response.status_code").AsInt64())
+
+	read := findSpan(t, spans, "Destination.Read")
+	assert.Equal(codes.Error, read.Status.Code)
+	assert.Equal(int64(404), spanAttr(read, "http.response.status_code").AsInt64())
+	assert.Len(read.Events, 1)
+	assert.Equal("exception", read.Events[0].Name)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(reader.Collect(ctx, &rm))
+	calls := map[string]int64{}
+	durations := map[string]uint64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					method, _ := dp.Attributes.Value(simplenotificationotel.MethodKey)
+					errType, _ := dp.Attributes.Value("error.type")
+					calls[method.AsString()+errType.AsString()] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					method, _ := dp.Attributes.Value(simplenotificationotel.MethodKey)
+					durations[method.AsString()] += dp.Count
+				}
+			}
+		}
+	}
+	assert.Equal(map[string]int64{
+		"Destination.Create":  1,
+		"Group.Create":        1,
+		"Group.SendMessage":   1,
+		"Destination.Read404": 1,
+	}, calls)
+	assert.Equal(uint64(1), durations["Group.SendMessage"])
+}
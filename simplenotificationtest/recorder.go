@@ -0,0 +1,285 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+)
+
+// Mode selects whether a Recorder talks to the real API or replays a cassette
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette without network access
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests and saves the interactions into the cassette
+	ModeRecord
+)
+
+const (
+	redactedEmail   = "redacted@example.com"
+	redactedWebhook = "https://example.com/redacted"
+)
+
+// Cassette is the golden file format of a Recorder
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a pair of a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request of an Interaction. Headers are not recorded so that credentials never reach a cassette.
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response of an Interaction
+type RecordedResponse struct {
+	StatusCode  int             `json:"status_code"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+}
+
+// Recorder is a saclient middleware recording API interactions into a cassette file, or replaying them from it.
+//
+// Requests are matched on method, path and the JSON filter query, in recorded order.
+// Email addresses and webhook URLs are redacted before they are saved.
+type Recorder struct {
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder for the cassette at path.
+// In ModeReplay the cassette is loaded immediately; a missing file yields an error wrapping fs.ErrNotExist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+	if mode == ModeRecord {
+		return r, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &r.cassette); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Mode returns the mode of the Recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Middleware returns the saclient middleware. Pass it to saclient.WithMiddleware.
+func (r *Recorder) Middleware() saclient.Middleware {
+	return func(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+		if r.mode == ModeReplay {
+			return r.replay(req)
+		}
+		return r.record(req, pull)
+	}
+}
+
+// Save writes the recorded interactions into the cassette file. It does nothing in ModeReplay.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(b, '\n'), 0o644)
+}
+
+func (r *Recorder) record(req *http.Request, pull func() (saclient.Middleware, bool)) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+	cont, ok := pull()
+	if !ok {
+		return nil, errors.New("middleware not found error")
+	}
+	resp, err := cont(req, pull)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  normalizeQuery(req.URL.RawQuery),
+			Body:   rawJSON(Redact(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        rawJSON(Redact(respBody)),
+		},
+	})
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	query := normalizeQuery(req.URL.RawQuery)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.Path != req.URL.Path || in.Request.Query != query {
+			continue
+		}
+		r.used[i] = true
+		body := []byte(in.Response.Body)
+		header := make(http.Header)
+		if in.Response.ContentType != "" {
+			header.Set("Content-Type", in.Response.ContentType)
+		}
+		header.Set("Content-Length", strconv.Itoa(len(body)))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction for %s %s in %s", req.Method, req.URL.RequestURI(), r.path)
+}
+
+// normalizeQuery decodes the JSON-only filter query so that escaping and key order do not affect matching
+func normalizeQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	q, err := url.QueryUnescape(raw)
+	if err != nil {
+		return raw
+	}
+	var v any
+	if err := json.Unmarshal([]byte(q), &v); err != nil {
+		return q
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return q
+	}
+	return string(b)
+}
+
+// rawJSON returns b as a JSON value, quoting it when it is not JSON
+func rawJSON(b []byte) json.RawMessage {
+	if len(b) == 0 {
+		return nil
+	}
+	if json.Valid(b) {
+		return b
+	}
+	quoted, _ := json.Marshal(string(b))
+	return quoted
+}
+
+// Redact replaces the email addresses and the URLs matched by simplenotification.EmailPattern and
+// simplenotification.URLPattern in a JSON body with placeholders, so that a webhook URL is redacted
+// from an error message as well as from the value of its destination
+func Redact(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return []byte(redactString(string(body)))
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return []byte(redactString(string(body)))
+	}
+	return b
+}
+
+func redactString(s string) string {
+	s = simplenotification.EmailPattern.ReplaceAllString(s, redactedEmail)
+	return simplenotification.URLPattern.ReplaceAllString(s, redactedWebhook)
+}
+
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = redactValue(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = redactValue(e)
+		}
+		return v
+	case string:
+		return redactString(v)
+	}
+	return v
+}
@@ -0,0 +1,142 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationtest_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+	"github.com/stretchr/testify/require"
+)
+
+func recorderClient(t *testing.T, rec *simplenotificationtest.Recorder, apiRootURL string) *v1.Client {
+	var saClient saclient.Client
+	if err := saClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+	if err := saClient.SetWith(saclient.WithMiddleware(rec.Middleware())); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+	client, err := simplenotification.NewClientWithAPIRootURL(&saClient, apiRootURL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestRecorder(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	srv := simplenotificationtest.NewServer()
+	rec, err := simplenotificationtest.NewRecorder(path, simplenotificationtest.ModeRecord)
+	assert.NoError(err)
+	client := recorderClient(t, rec, srv.URL)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+
+	created, err := destinationAPI.Create(ctx, destinationRequest("dest", "alice@example.com"))
+	assert.NoError(err)
+	id := created.CommonServiceItem.ID
+	_, err = groupAPI.List(ctx)
+	assert.NoError(err)
+	destinations, err := destinationAPI.List(ctx)
+	assert.NoError(err)
+	assert.Len(destinations.CommonServiceItems, 1)
+	_, err = destinationAPI.Read(ctx, "123456789012")
+	assert.True(saclient.IsNotFoundError(err))
+	assert.NoError(rec.Save())
+	srv.Close()
+
+	golden, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.NotContains(string(golden), "alice@example.com")
+	assert.Contains(string(golden), "redacted@example.com")
+
+	rec, err = simplenotificationtest.NewRecorder(path, simplenotificationtest.ModeReplay)
+	assert.NoError(err)
+	client = recorderClient(t, rec, srv.URL)
+	destinationAPI = simplenotification.NewDestinationOp(client)
+
+	// the filter query tells the destination list apart from the group list
+	destinations, err = destinationAPI.List(ctx)
+	assert.NoError(err)
+	assert.Len(destinations.CommonServiceItems, 1)
+	assert.Equal(id, destinations.CommonServiceItems[0].ID)
+	assert.Equal("redacted@example.com", destinations.CommonServiceItems[0].Settings.CommonServiceItemDestinationSettings.Value)
+
+	_, err = destinationAPI.Read(ctx, "123456789012")
+	assert.True(saclient.IsNotFoundError(err))
+
+	// every interaction is replayed once
+	_, err = destinationAPI.List(ctx)
+	assert.ErrorContains(err, "no recorded interaction")
+}
+
+func TestNewRecorder_MissingCassette(t *testing.T) {
+	_, err := simplenotificationtest.NewRecorder(filepath.Join(t.TempDir(), "missing.json"), simplenotificationtest.ModeReplay)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "email destination",
+			in:   `{"Settings":{"Type":"email","Value":"alice@example.com"}}`,
+			want: `{"Settings":{"Type":"email","Value":"redacted@example.com"}}`,
+		},
+		{
+			name: "webhook destination",
+			in:   `{"Settings":{"Type":"webhook","Value":"https://hooks.example.com/services/T000/B000/XXXX"}}`,
+			want: `{"Settings":{"Type":"webhook","Value":"https://example.com/redacted"}}`,
+		},
+		{
+			name: "email in message",
+			in:   `{"Message":"contact bob@example.co.jp"}`,
+			want: `{"Message":"contact redacted@example.com"}`,
+		},
+		{
+			name: "webhook URL in error message",
+			in:   `{"error_msg":"failed to post to https://hooks.example.com/services/T000 for ops@example.com"}`,
+			want: `{"error_msg":"failed to post to https://example.com/redacted for redacted@example.com"}`,
+		},
+		{
+			name: "non-ASCII email",
+			in:   `{"Settings":{"Type":"email","Value":"あいう@example.jp"}}`,
+			want: `{"Settings":{"Type":"email","Value":"redacted@example.com"}}`,
+		},
+		{
+			name: "large number",
+			in:   `{"ID":123456789012345678}`,
+			want: `{"ID":123456789012345678}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.JSONEq(t, tt.want, string(simplenotificationtest.Redact([]byte(tt.in))))
+		})
+	}
+}
@@ -0,0 +1,561 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package simplenotificationtest provides an in-memory fake of the simple-notification API
+// for tests that must not reach the real service.
+//
+// The fake implements every operation of apis/v1 on top of net/http/httptest.
+// Point a client at it with:
+//
+//	srv := simplenotificationtest.NewServer()
+//	defer srv.Close()
+//	client, err := simplenotification.NewClientWithAPIRootURL(&saclient.Client{}, srv.URL)
+package simplenotificationtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-faster/jx"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+const (
+	// maxHistories is the number of histories returned by the history list API
+	maxHistories = 100
+	// idBase is the first resource ID handed out by the fake; IDs are always 12 digits
+	idBase = 113700000000
+)
+
+// DefaultSources are the notification sources served when WithSources is not given
+var DefaultSources = []v1.ListSourcesResponseSourcesItem{
+	{ID: "1", Name: "SimpleMonitor"},
+	{ID: "2", Name: "AppRun"},
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithClock replaces the clock used for CreatedAt, ModifiedAt and history timestamps
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) { s.now = now }
+}
+
+// WithSources replaces the notification sources returned by ListSources
+func WithSources(sources ...v1.ListSourcesResponseSourcesItem) Option {
+	return func(s *Server) { s.sources = slices.Clone(sources) }
+}
+
+// Server is an in-memory simple-notification API server
+type Server struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	now          func() time.Time
+	lastID       int64
+	lastReqID    int64
+	lastStatusID int64
+	items        map[string]v1.CommonServiceItem
+	order        []string
+	invalid      map[string]bool
+	histories    []v1.NotificationHistory
+	sources      []v1.ListSourcesResponseSourcesItem
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		now:     func() time.Time { return time.Now().In(simplenotification.JST).Truncate(time.Second) },
+		items:   make(map[string]v1.CommonServiceItem),
+		invalid: make(map[string]bool),
+		sources: slices.Clone(DefaultSources),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(s.handler())
+	return s
+}
+
+// SetDestinationValid sets the IsValid flag returned by the destination status API
+func (s *Server) SetDestinationValid(id string, valid bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if valid {
+		delete(s.invalid, id)
+	} else {
+		s.invalid[id] = true
+	}
+}
+
+// SetDeliveryStatus rewrites the status of the delivery to destinationID made by the request requestID
+func (s *Server) SetDeliveryStatus(requestID, destinationID string, status v1.NotificationStatusStatus, errorInfo string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.histories {
+		if s.histories[i].RequestID != requestID {
+			continue
+		}
+		for j := range s.histories[i].Statuses {
+			st := &s.histories[i].Statuses[j]
+			if st.DestinationID == destinationID {
+				st.Status = status
+				st.ErrorInfo = errorInfo
+				st.UpdatedAt = s.now()
+				return nil
+			}
+		}
+		return fmt.Errorf("destination %s not found in request %s", destinationID, requestID)
+	}
+	return fmt.Errorf("request %s not found", requestID)
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /commonserviceitem", s.listItems)
+	mux.HandleFunc("POST /commonserviceitem", s.createItem)
+	mux.HandleFunc("GET /commonserviceitem/{id}", s.getItem)
+	mux.HandleFunc("PUT /commonserviceitem/{id}", s.updateItem)
+	mux.HandleFunc("DELETE /commonserviceitem/{id}", s.deleteItem)
+	mux.HandleFunc("GET /commonserviceitem/{id}/simplenotification/status", s.getStatus)
+	mux.HandleFunc("POST /commonserviceitem/{id}/simplenotification/message", s.sendMessage)
+	mux.HandleFunc("GET /commonserviceitem/simplenotification/history", s.listHistories)
+	mux.HandleFunc("GET /commonserviceitem/simplenotification/history/{request_id}", s.getHistory)
+	mux.HandleFunc("GET /commonserviceitem/simplenotification/sources", s.listSources)
+	mux.HandleFunc("PUT /commonserviceitem/simplenotification/routing/reorder", s.reorderRouting)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", "%s %s is not found", r.Method, r.URL.Path)
+	})
+	return mux
+}
+
+func (s *Server) listItems(w http.ResponseWriter, r *http.Request) {
+	class, err := providerClassFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid filter: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := []v1.CommonServiceItem{}
+	for _, id := range s.order {
+		item := s.items[id]
+		if class != "" && item.Provider.Class != class {
+			continue
+		}
+		item.Index = v1.NewOptInt(len(items))
+		items = append(items, item)
+	}
+	writeJSON(w, http.StatusOK, &v1.ListCommonServiceItemsResponse{
+		From:               v1.NewOptInt(0),
+		Count:              v1.NewOptInt(len(items)),
+		Total:              v1.NewOptInt(len(items)),
+		CommonServiceItems: items,
+	})
+}
+
+func (s *Server) createItem(w http.ResponseWriter, r *http.Request) {
+	var req v1.PostCommonServiceItemRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	in := req.CommonServiceItem
+	class := v1.CommonServiceItemProviderClass(in.Provider.Class)
+	settings := postSettings(in.Settings)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.validateItem(class, in.Name, in.Description, in.Tags, settings); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "%v", err)
+		return
+	}
+	if class == v1.CommonServiceItemProviderClassSaknoticerouting {
+		// PriorityRank is decided by the service; the requested value is ignored
+		settings.CommonServiceItemRoutingSettings.PriorityRank = s.nextPriorityRank()
+	}
+	s.lastID++
+	now := s.now()
+	item := v1.CommonServiceItem{
+		ID:          strconv.FormatInt(idBase+s.lastID, 10),
+		Name:        in.Name,
+		Description: in.Description,
+		Settings:    settings,
+		CreatedAt:   now,
+		ModifiedAt:  now,
+		Provider:    v1.CommonServiceItemProvider{Class: class},
+		Icon:        icon(in.Icon),
+		Tags:        tags(in.Tags),
+	}
+	s.items[item.ID] = item
+	s.order = append(s.order, item.ID)
+	writeJSON(w, http.StatusCreated, &v1.CreateCommonServiceItemCreated{CommonServiceItem: item})
+}
+
+func (s *Server) getItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.lookup(w, r.PathValue("id"), "")
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetCommonServiceItemOK{CommonServiceItem: item})
+}
+
+func (s *Server) updateItem(w http.ResponseWriter, r *http.Request) {
+	var req v1.PutCommonServiceItemRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	in := req.CommonServiceItem
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.lookup(w, r.PathValue("id"), "")
+	if !ok {
+		return
+	}
+	settings := item.Settings
+	if v, ok := in.Settings.Get(); ok {
+		settings = putSettings(v)
+		if item.Provider.Class == v1.CommonServiceItemProviderClassSaknoticerouting {
+			// PriorityRank can only be changed by the reorder API
+			settings.CommonServiceItemRoutingSettings.PriorityRank = item.Settings.CommonServiceItemRoutingSettings.PriorityRank
+		}
+	}
+	if err := s.validateItem(item.Provider.Class, in.Name, in.Description, in.Tags, settings); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "%v", err)
+		return
+	}
+	item.Name = in.Name
+	item.Description = in.Description
+	item.Tags = tags(in.Tags)
+	item.Icon = icon(in.Icon)
+	item.Settings = settings
+	item.ModifiedAt = s.now()
+	s.items[item.ID] = item
+	writeJSON(w, http.StatusOK, &v1.UpdateCommonServiceItemOK{CommonServiceItem: item})
+}
+
+func (s *Server) deleteItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.lookup(w, r.PathValue("id"), "")
+	if !ok {
+		return
+	}
+	if ref, ok := s.referrer(item.ID); ok {
+		writeError(w, http.StatusConflict, "conflict", "resource %s is in use by %s", item.ID, ref)
+		return
+	}
+	delete(s.items, item.ID)
+	delete(s.invalid, item.ID)
+	s.order = slices.DeleteFunc(s.order, func(id string) bool { return id == item.ID })
+	writeJSON(w, http.StatusOK, &v1.DeleteCommonServiceItemOK{CommonServiceItem: item})
+}
+
+func (s *Server) getStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.lookup(w, r.PathValue("id"), v1.CommonServiceItemProviderClassSaknoticedestination)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, &v1.GetCommonServiceItemStatusResponse{
+		NotificationStatus: v1.GetCommonServiceItemStatusResponseNotificationStatus{
+			IsValid:    !s.invalid[item.ID],
+			ModifiedAt: item.ModifiedAt,
+		},
+	})
+}
+
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	var req v1.SendNotificationMessageRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Message == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "Message is required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	group, ok := s.lookup(w, r.PathValue("id"), v1.CommonServiceItemProviderClassSaknoticegroup)
+	if !ok {
+		return
+	}
+	if group.Settings.CommonServiceItemGroupSettings.Disabled.Or(false) {
+		writeError(w, http.StatusBadRequest, "bad_request", "group %s is disabled", group.ID)
+		return
+	}
+
+	s.lastReqID++
+	now := s.now()
+	history := v1.NotificationHistory{
+		RequestID:  strconv.FormatInt(s.lastReqID, 10),
+		Statuses:   []v1.NotificationStatus{},
+		ReceivedAt: now,
+		Message: v1.NotificationMessage{
+			Body:      req.Message,
+			Color:     "default",
+			ColorCode: "#7d7d7d",
+		},
+	}
+	for _, destID := range group.Settings.CommonServiceItemGroupSettings.Destinations {
+		dest, ok := s.items[destID]
+		if !ok || dest.Settings.CommonServiceItemDestinationSettings.Disabled.Or(false) {
+			continue
+		}
+		s.lastStatusID++
+		history.Statuses = append(history.Statuses, v1.NotificationStatus{
+			ID:                    strconv.FormatInt(s.lastStatusID, 10),
+			Status:                v1.NotificationStatusStatus2,
+			NotificationRequestID: history.RequestID,
+			GroupID:               group.ID,
+			DestinationID:         destID,
+			CreatedAt:             now,
+			UpdatedAt:             now,
+		})
+	}
+	s.histories = append([]v1.NotificationHistory{history}, s.histories...)
+	writeJSON(w, http.StatusAccepted, &v1.SendNotificationMessageResponse{IsOk: true})
+}
+
+func (s *Server) listHistories(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	histories := s.histories[:min(len(s.histories), maxHistories)]
+	writeJSON(w, http.StatusOK, &v1.ListSimpleNotificationHistoriesResponse{
+		NotificationHistories: append([]v1.NotificationHistory{}, histories...),
+	})
+}
+
+func (s *Server) getHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("request_id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, h := range s.histories {
+		if h.RequestID == id {
+			writeJSON(w, http.StatusOK, &v1.GetSimpleNotificationHistoryResponse{NotificationHistory: h})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "notification history %s is not found", id)
+}
+
+func (s *Server) listSources(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, &v1.ListSourcesResponse{
+		Sources: append([]v1.ListSourcesResponseSourcesItem{}, s.sources...),
+	})
+}
+
+func (s *Server) reorderRouting(w http.ResponseWriter, r *http.Request) {
+	var req v1.PutCommonServiceItemRoutingReorderRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ranks := make(map[string]int)
+	for _, id := range s.order {
+		if item := s.items[id]; item.Provider.Class == v1.CommonServiceItemProviderClassSaknoticerouting {
+			ranks[id] = item.Settings.CommonServiceItemRoutingSettings.PriorityRank
+		}
+	}
+	for _, o := range req.Orders {
+		if _, ok := ranks[o.RoutingID]; !ok {
+			writeError(w, http.StatusBadRequest, "bad_request", "routing %s is not found", o.RoutingID)
+			return
+		}
+		ranks[o.RoutingID] = o.PriorityRank
+	}
+	if err := uniqueRanks(ranks); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "%v", err)
+		return
+	}
+	now := s.now()
+	for _, o := range req.Orders {
+		item := s.items[o.RoutingID]
+		item.Settings.CommonServiceItemRoutingSettings.PriorityRank = o.PriorityRank
+		item.ModifiedAt = now
+		s.items[o.RoutingID] = item
+	}
+	writeJSON(w, http.StatusAccepted, &v1.ReorderRoutingAccepted{IsOk: v1.NewOptBool(true)})
+}
+
+// lookup finds the resource by id, writing a not found error when it is absent or,
+// if class is given, of a different class.
+func (s *Server) lookup(w http.ResponseWriter, id string, class v1.CommonServiceItemProviderClass) (v1.CommonServiceItem, bool) {
+	item, ok := s.items[id]
+	if !ok || (class != "" && item.Provider.Class != class) {
+		writeError(w, http.StatusNotFound, "not_found", "resource %s is not found", id)
+		return item, false
+	}
+	return item, true
+}
+
+// nextPriorityRank returns the rank given to a new routing, which is placed after the existing ones
+func (s *Server) nextPriorityRank() int {
+	rank := 0
+	for _, item := range s.items {
+		if item.Provider.Class == v1.CommonServiceItemProviderClassSaknoticerouting {
+			rank = max(rank, item.Settings.CommonServiceItemRoutingSettings.PriorityRank)
+		}
+	}
+	return rank + 1
+}
+
+// referrer returns the ID of a resource referring to id
+func (s *Server) referrer(id string) (string, bool) {
+	for _, ref := range s.order {
+		item := s.items[ref]
+		switch item.Provider.Class {
+		case v1.CommonServiceItemProviderClassSaknoticegroup:
+			if slices.Contains(item.Settings.CommonServiceItemGroupSettings.Destinations, id) {
+				return ref, true
+			}
+		case v1.CommonServiceItemProviderClassSaknoticerouting:
+			if item.Settings.CommonServiceItemRoutingSettings.TargetGroupID == id {
+				return ref, true
+			}
+		}
+	}
+	return "", false
+}
+
+type filterQuery struct {
+	Filter map[string]string `json:"Filter"`
+}
+
+// providerClassFilter reads the JSON-only query used by the list API, e.g. ?{"Filter":{"Provider.Class":"saknoticegroup"}}
+func providerClassFilter(r *http.Request) (v1.CommonServiceItemProviderClass, error) {
+	if r.URL.RawQuery == "" {
+		return "", nil
+	}
+	raw, err := url.QueryUnescape(r.URL.RawQuery)
+	if err != nil {
+		return "", err
+	}
+	var q filterQuery
+	if err := json.Unmarshal([]byte(raw), &q); err != nil {
+		return "", err
+	}
+	v, ok := q.Filter["Provider.Class"]
+	if !ok {
+		return "", nil
+	}
+	class := v1.CommonServiceItemProviderClass(v)
+	if err := class.Validate(); err != nil {
+		return "", err
+	}
+	return class, nil
+}
+
+type request interface {
+	Decode(d *jx.Decoder) error
+	Validate() error
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, req request) bool {
+	d := jx.Decode(r.Body, 4096)
+	if err := req.Decode(d); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid request body: %v", err)
+		return false
+	}
+	if err := req.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "invalid request body: %v", err)
+		return false
+	}
+	return true
+}
+
+type response interface {
+	Encode(e *jx.Encoder)
+}
+
+func writeJSON(w http.ResponseWriter, code int, res response) {
+	var e jx.Encoder
+	res.Encode(&e)
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	_, _ = w.Write(e.Bytes())
+}
+
+func writeError(w http.ResponseWriter, code int, errorCode string, format string, args ...any) {
+	serial := make([]byte, 16)
+	_, _ = rand.Read(serial)
+	writeJSON(w, code, &v1.Error{
+		IsFatal:   v1.NewOptBool(true),
+		Serial:    v1.NewOptString(hex.EncodeToString(serial)),
+		Status:    v1.NewOptString(fmt.Sprintf("%d %s", code, http.StatusText(code))),
+		ErrorCode: v1.NewOptString(errorCode),
+		ErrorMsg:  v1.NewOptString(fmt.Sprintf(format, args...)),
+	})
+}
+
+// icon returns the icon stored for the request. Like the real API, a resource without icon has a null Icon
+// and the icon ID "0" erases the icon.
+func icon(in v1.NilIcon) v1.NilIcon {
+	v, ok := in.Get()
+	if !ok || !v.ID.Set || v.ID.Value == "" || v.ID.Value == "0" {
+		return v1.NilIcon{Null: true}
+	}
+	return v1.NewNilIcon(v1.Icon{ID: v.ID, Tags: []string{}})
+}
+
+func tags(in []string) []string {
+	if in == nil {
+		return []string{}
+	}
+	return slices.Clone(in)
+}
+
+func postSettings(in v1.PostCommonServiceItemRequestCommonServiceItemSettings) v1.CommonServiceItemSettings {
+	switch in.Type {
+	case v1.CommonServiceItemDestinationSettingsPostCommonServiceItemRequestCommonServiceItemSettings:
+		return v1.NewCommonServiceItemDestinationSettingsCommonServiceItemSettings(in.CommonServiceItemDestinationSettings)
+	case v1.CommonServiceItemGroupSettingsPostCommonServiceItemRequestCommonServiceItemSettings:
+		return v1.NewCommonServiceItemGroupSettingsCommonServiceItemSettings(in.CommonServiceItemGroupSettings)
+	case v1.CommonServiceItemRoutingSettingsPostCommonServiceItemRequestCommonServiceItemSettings:
+		return v1.NewCommonServiceItemRoutingSettingsCommonServiceItemSettings(in.CommonServiceItemRoutingSettings)
+	}
+	return v1.CommonServiceItemSettings{}
+}
+
+func putSettings(in v1.PutCommonServiceItemRequestCommonServiceItemSettings) v1.CommonServiceItemSettings {
+	switch in.Type {
+	case v1.CommonServiceItemDestinationSettingsPutCommonServiceItemRequestCommonServiceItemSettings:
+		return v1.NewCommonServiceItemDestinationSettingsCommonServiceItemSettings(in.CommonServiceItemDestinationSettings)
+	case v1.CommonServiceItemGroupSettingsPutCommonServiceItemRequestCommonServiceItemSettings:
+		return v1.NewCommonServiceItemGroupSettingsCommonServiceItemSettings(in.CommonServiceItemGroupSettings)
+	case v1.CommonServiceItemRoutingSettingsPutCommonServiceItemRequestCommonServiceItemSettings:
+		return v1.NewCommonServiceItemRoutingSettingsCommonServiceItemSettings(in.CommonServiceItemRoutingSettings)
+	}
+	return v1.CommonServiceItemSettings{}
+}
@@ -0,0 +1,302 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationtest_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationtest"
+	"github.com/stretchr/testify/require"
+)
+
+func setup(t *testing.T) (context.Context, *simplenotificationtest.Server, *v1.Client) {
+	srv := simplenotificationtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	var saClient saclient.Client
+	if err := saClient.SetEnviron([]string{"SAKURA_RATE_LIMIT=1000"}); err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+	client, err := simplenotification.NewClientWithAPIRootURL(&saClient, srv.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return t.Context(), srv, client
+}
+
+func destinationRequest(name, mailAddress string) v1.PostCommonServiceItemRequest {
+	return v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: name,
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemDestinationSettings: v1.CommonServiceItemDestinationSettings{
+					Type:  v1.CommonServiceItemDestinationSettingsTypeEmail,
+					Value: mailAddress,
+				},
+			},
+		},
+	}
+}
+
+func groupRequest(name string, destinations ...string) v1.PostCommonServiceItemRequest {
+	return v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: name,
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemGroupSettings: v1.CommonServiceItemGroupSettings{
+					Destinations: destinations,
+				},
+			},
+		},
+	}
+}
+
+func routingRequest(name, sourceID, groupID string, rank int) v1.PostCommonServiceItemRequest {
+	return v1.PostCommonServiceItemRequest{
+		CommonServiceItem: v1.PostCommonServiceItemRequestCommonServiceItem{
+			Name: name,
+			Tags: []string{},
+			Settings: v1.PostCommonServiceItemRequestCommonServiceItemSettings{
+				CommonServiceItemRoutingSettings: v1.CommonServiceItemRoutingSettings{
+					MatchLabels:   []v1.CommonServiceItemRoutingSettingsMatchLabelsItem{},
+					SourceID:      sourceID,
+					TargetGroupID: groupID,
+					PriorityRank:  rank,
+				},
+			},
+		},
+	}
+}
+
+func requireStatusCode(t *testing.T, err error, code int) {
+	t.Helper()
+	var e *v1.ErrorStatusCode
+	require.True(t, errors.As(err, &e), "expected API error but got %v", err)
+	require.Equal(t, code, e.StatusCode)
+}
+
+func TestServer_Destination(t *testing.T) {
+	assert := require.New(t)
+	ctx, _, client := setup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+
+	created, err := destinationAPI.Create(ctx, destinationRequest("dest", "alice@example.com"))
+	assert.NoError(err)
+	id := created.CommonServiceItem.ID
+	assert.Regexp(`^[0-9]{12}$`, id)
+	assert.Equal(v1.CommonServiceItemProviderClassSaknoticedestination, created.CommonServiceItem.Provider.Class)
+	// null Icon is rewritten by the middleware into an empty object
+	assert.False(created.CommonServiceItem.Icon.Null)
+
+	_, err = groupAPI.Create(ctx, groupRequest("group", id))
+	assert.NoError(err)
+
+	list, err := destinationAPI.List(ctx)
+	assert.NoError(err)
+	assert.Len(list.CommonServiceItems, 1)
+	assert.Equal(id, list.CommonServiceItems[0].ID)
+
+	read, err := destinationAPI.Read(ctx, id)
+	assert.NoError(err)
+	assert.Equal("alice@example.com", read.CommonServiceItem.Settings.CommonServiceItemDestinationSettings.Value)
+
+	updated, err := destinationAPI.Update(ctx, id, v1.PutCommonServiceItemRequest{
+		CommonServiceItem: v1.PutCommonServiceItemRequestCommonServiceItem{
+			Name: "dest-updated",
+			Tags: []string{"updated"},
+		},
+	})
+	assert.NoError(err)
+	assert.Equal("dest-updated", updated.CommonServiceItem.Name)
+	assert.Equal([]string{"updated"}, updated.CommonServiceItem.Tags)
+	assert.Equal("alice@example.com", updated.CommonServiceItem.Settings.CommonServiceItemDestinationSettings.Value)
+
+	status, err := destinationAPI.GetStatus(ctx, id)
+	assert.NoError(err)
+	assert.True(status.NotificationStatus.IsValid)
+}
+
+func TestServer_Icon(t *testing.T) {
+	assert := require.New(t)
+	ctx, _, client := setup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	hasIcon := func(id string) bool {
+		read, err := destinationAPI.Read(ctx, id)
+		assert.NoError(err)
+		icon, ok := read.CommonServiceItem.Icon.Get()
+		return ok && icon.ID.Set
+	}
+
+	request := destinationRequest("dest", "alice@example.com")
+	request.CommonServiceItem.Icon = v1.NewNilIcon(v1.Icon{ID: v1.NewOptString("112900000001")})
+	created, err := destinationAPI.Create(ctx, request)
+	assert.NoError(err)
+	id := created.CommonServiceItem.ID
+	assert.True(hasIcon(id))
+
+	// the icon ID "0" erases the icon
+	_, err = destinationAPI.Update(ctx, id, v1.PutCommonServiceItemRequest{
+		CommonServiceItem: v1.PutCommonServiceItemRequestCommonServiceItem{
+			Name: "dest",
+			Tags: []string{},
+			Icon: v1.NewNilIcon(v1.Icon{ID: v1.NewOptString("0")}),
+		},
+	})
+	assert.NoError(err)
+	assert.False(hasIcon(id))
+
+	request.CommonServiceItem.Icon = v1.NewNilIcon(v1.Icon{ID: v1.NewOptString("0")})
+	created, err = destinationAPI.Create(ctx, request)
+	assert.NoError(err)
+	assert.False(hasIcon(created.CommonServiceItem.ID))
+}
+
+func TestServer_Validation(t *testing.T) {
+	ctx, _, client := setup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+	routingAPI := simplenotification.NewRoutingOp(client)
+
+	t.Run("invalid email", func(t *testing.T) {
+		_, err := destinationAPI.Create(ctx, destinationRequest("dest", "not an address"))
+		requireStatusCode(t, err, 400)
+	})
+	t.Run("missing name", func(t *testing.T) {
+		_, err := destinationAPI.Create(ctx, destinationRequest("", "alice@example.com"))
+		requireStatusCode(t, err, 400)
+	})
+	t.Run("unknown group member", func(t *testing.T) {
+		_, err := groupAPI.Create(ctx, groupRequest("group", "123456789012"))
+		requireStatusCode(t, err, 400)
+	})
+	t.Run("unknown source", func(t *testing.T) {
+		_, err := routingAPI.Create(ctx, routingRequest("routing", "999", "123456789012", 1))
+		requireStatusCode(t, err, 400)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := destinationAPI.Read(ctx, "123456789012")
+		require.True(t, saclient.IsNotFoundError(err), "expected not found but got %v", err)
+	})
+}
+
+func TestServer_SendMessage(t *testing.T) {
+	assert := require.New(t)
+	ctx, srv, client := setup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+	historyAPI := simplenotification.NewHistoryOp(client)
+
+	dest, err := destinationAPI.Create(ctx, destinationRequest("dest", "alice@example.com"))
+	assert.NoError(err)
+	destID := dest.CommonServiceItem.ID
+	group, err := groupAPI.Create(ctx, groupRequest("group", destID))
+	assert.NoError(err)
+	groupID := group.CommonServiceItem.ID
+
+	_, err = groupAPI.SendMessage(ctx, destID, v1.SendNotificationMessageRequest{Message: "hello"})
+	requireStatusCode(t, err, 404)
+	_, err = groupAPI.SendMessage(ctx, groupID, v1.SendNotificationMessageRequest{Message: strings.Repeat("あ", 2049)})
+	assert.Error(err, "message longer than 2048 characters must be rejected")
+
+	res, err := groupAPI.SendMessage(ctx, groupID, v1.SendNotificationMessageRequest{Message: "hello"})
+	assert.NoError(err)
+	assert.True(res.IsOk)
+
+	histories, err := historyAPI.List(ctx)
+	assert.NoError(err)
+	assert.Len(histories.NotificationHistories, 1)
+	history := histories.NotificationHistories[0]
+	assert.Equal("hello", history.Message.Body)
+	assert.Len(history.Statuses, 1)
+	assert.Equal(groupID, history.Statuses[0].GroupID)
+	assert.Equal(destID, history.Statuses[0].DestinationID)
+	assert.Equal(v1.NotificationStatusStatus2, history.Statuses[0].Status)
+
+	assert.NoError(srv.SetDeliveryStatus(history.RequestID, destID, v1.NotificationStatusStatus9, "mailbox full"))
+	read, err := historyAPI.Read(ctx, history.RequestID)
+	assert.NoError(err)
+	assert.Equal(v1.NotificationStatusStatus9, read.NotificationHistory.Statuses[0].Status)
+	assert.Equal("mailbox full", read.NotificationHistory.Statuses[0].ErrorInfo)
+
+	err = destinationAPI.Delete(ctx, destID)
+	requireStatusCode(t, err, 409)
+	assert.NoError(groupAPI.Delete(ctx, groupID))
+	assert.NoError(destinationAPI.Delete(ctx, destID))
+}
+
+func TestServer_Routing(t *testing.T) {
+	assert := require.New(t)
+	ctx, _, client := setup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+	routingAPI := simplenotification.NewRoutingOp(client)
+
+	sources, err := routingAPI.ListSource(ctx)
+	assert.NoError(err)
+	assert.Equal(simplenotificationtest.DefaultSources, sources.Sources)
+	sourceID := sources.Sources[0].ID
+
+	dest, err := destinationAPI.Create(ctx, destinationRequest("dest", "alice@example.com"))
+	assert.NoError(err)
+	group, err := groupAPI.Create(ctx, groupRequest("group", dest.CommonServiceItem.ID))
+	assert.NoError(err)
+	groupID := group.CommonServiceItem.ID
+
+	// PriorityRank is assigned by the server
+	first, err := routingAPI.Create(ctx, routingRequest("first", sourceID, groupID, 1))
+	assert.NoError(err)
+	assert.Equal(1, first.CommonServiceItem.Settings.CommonServiceItemRoutingSettings.PriorityRank)
+	second, err := routingAPI.Create(ctx, routingRequest("second", sourceID, groupID, 1))
+	assert.NoError(err)
+	assert.Equal(2, second.CommonServiceItem.Settings.CommonServiceItemRoutingSettings.PriorityRank)
+	// a requested rank already taken is ignored instead of being rejected
+	third, err := routingAPI.Create(ctx, routingRequest("third", sourceID, groupID, 2))
+	assert.NoError(err)
+	assert.Equal(3, third.CommonServiceItem.Settings.CommonServiceItemRoutingSettings.PriorityRank)
+	assert.NoError(routingAPI.Delete(ctx, third.CommonServiceItem.ID))
+
+	_, err = routingAPI.Reorder(ctx, v1.PutCommonServiceItemRoutingReorderRequest{
+		Orders: []v1.PutCommonServiceItemRoutingReorderRequestOrdersItem{
+			{RoutingID: first.CommonServiceItem.ID, PriorityRank: 2},
+		},
+	})
+	requireStatusCode(t, err, 400)
+
+	_, err = routingAPI.Reorder(ctx, v1.PutCommonServiceItemRoutingReorderRequest{
+		Orders: []v1.PutCommonServiceItemRoutingReorderRequestOrdersItem{
+			{RoutingID: first.CommonServiceItem.ID, PriorityRank: 2},
+			{RoutingID: second.CommonServiceItem.ID, PriorityRank: 1},
+		},
+	})
+	assert.NoError(err)
+
+	list, err := routingAPI.List(ctx)
+	assert.NoError(err)
+	assert.Len(list.CommonServiceItems, 2)
+	assert.Equal(2, list.CommonServiceItems[0].Settings.CommonServiceItemRoutingSettings.PriorityRank)
+	assert.Equal(1, list.CommonServiceItems[1].Settings.CommonServiceItemRoutingSettings.PriorityRank)
+
+	err = groupAPI.Delete(ctx, groupID)
+	requireStatusCode(t, err, 409)
+}
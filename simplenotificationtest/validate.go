@@ -0,0 +1,132 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotificationtest
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"unicode/utf8"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// limits shared by all SAKURA Cloud resources
+const (
+	maxNameLength        = 64
+	maxDescriptionLength = 512
+	maxTags              = 10
+	maxTagLength         = 32
+)
+
+var settingsTypeOfClass = map[v1.CommonServiceItemProviderClass]v1.CommonServiceItemSettingsType{
+	v1.CommonServiceItemProviderClassSaknoticedestination: v1.CommonServiceItemDestinationSettingsCommonServiceItemSettings,
+	v1.CommonServiceItemProviderClassSaknoticegroup:       v1.CommonServiceItemGroupSettingsCommonServiceItemSettings,
+	v1.CommonServiceItemProviderClassSaknoticerouting:     v1.CommonServiceItemRoutingSettingsCommonServiceItemSettings,
+}
+
+// validateItem checks a resource as the real API does
+func (s *Server) validateItem(class v1.CommonServiceItemProviderClass, name, description string, tags []string, settings v1.CommonServiceItemSettings) error {
+	if name == "" {
+		return errors.New("Name is required")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return fmt.Errorf("Name must be at most %d characters", maxNameLength)
+	}
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return fmt.Errorf("Description must be at most %d characters", maxDescriptionLength)
+	}
+	if len(tags) > maxTags {
+		return fmt.Errorf("Tags must be at most %d", maxTags)
+	}
+	for _, tag := range tags {
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLength {
+			return fmt.Errorf("Tags must be 1 to %d characters: %q", maxTagLength, tag)
+		}
+	}
+
+	want, ok := settingsTypeOfClass[class]
+	if !ok {
+		return fmt.Errorf("unknown Provider.Class: %q", class)
+	}
+	if settings.Type != want {
+		return fmt.Errorf("Settings for %s must be %s, got %q", class, want, settings.Type)
+	}
+	switch class {
+	case v1.CommonServiceItemProviderClassSaknoticedestination:
+		return validateDestinationSettings(settings.CommonServiceItemDestinationSettings)
+	case v1.CommonServiceItemProviderClassSaknoticegroup:
+		return s.validateGroupSettings(settings.CommonServiceItemGroupSettings)
+	case v1.CommonServiceItemProviderClassSaknoticerouting:
+		return s.validateRoutingSettings(settings.CommonServiceItemRoutingSettings)
+	}
+	return nil
+}
+
+func validateDestinationSettings(settings v1.CommonServiceItemDestinationSettings) error {
+	switch settings.Type {
+	case v1.CommonServiceItemDestinationSettingsTypeEmail:
+		addr, err := mail.ParseAddress(settings.Value)
+		if err != nil || addr.Address != settings.Value {
+			return fmt.Errorf("Settings.Value is not a valid email address: %q", settings.Value)
+		}
+	case v1.CommonServiceItemDestinationSettingsTypeWebhook:
+		u, err := url.Parse(settings.Value)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("Settings.Value is not a valid webhook URL: %q", settings.Value)
+		}
+	default:
+		return fmt.Errorf("unknown Settings.Type: %q", settings.Type)
+	}
+	return nil
+}
+
+func (s *Server) validateGroupSettings(settings v1.CommonServiceItemGroupSettings) error {
+	if len(settings.Destinations) == 0 {
+		return errors.New("Settings.Destinations is required")
+	}
+	for i, id := range settings.Destinations {
+		if item, ok := s.items[id]; !ok || item.Provider.Class != v1.CommonServiceItemProviderClassSaknoticedestination {
+			return fmt.Errorf("destination %s is not found", id)
+		}
+		if slices.Contains(settings.Destinations[:i], id) {
+			return fmt.Errorf("destination %s is duplicated", id)
+		}
+	}
+	return nil
+}
+
+func (s *Server) validateRoutingSettings(settings v1.CommonServiceItemRoutingSettings) error {
+	if !slices.ContainsFunc(s.sources, func(src v1.ListSourcesResponseSourcesItem) bool { return src.ID == settings.SourceID }) {
+		return fmt.Errorf("source %s is not found", settings.SourceID)
+	}
+	if item, ok := s.items[settings.TargetGroupID]; !ok || item.Provider.Class != v1.CommonServiceItemProviderClassSaknoticegroup {
+		return fmt.Errorf("group %s is not found", settings.TargetGroupID)
+	}
+	return nil
+}
+
+func uniqueRanks(ranks map[string]int) error {
+	seen := make(map[int]string, len(ranks))
+	for id, rank := range ranks {
+		if other, ok := seen[rank]; ok {
+			return fmt.Errorf("PriorityRank %d is used by both %s and %s", rank, min(id, other), max(id, other))
+		}
+		seen[rank] = id
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// SplitOption configures SplitMessage and SendSplit
+type SplitOption func(*splitConfig)
+
+type splitConfig struct {
+	maxParts      int
+	maxPartLength int
+	link          string
+	interval      time.Duration
+	sendOptions   []SendOption
+}
+
+func newSplitConfig(opts []SplitOption) splitConfig {
+	config := splitConfig{maxParts: 10, maxPartLength: MaxMessageLength, interval: time.Second}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// WithMaxParts caps the number of parts, 10 by default. The text beyond the last part is dropped
+// and replaced by a footer with the link of WithTruncatedLink.
+func WithMaxParts(n int) SplitOption {
+	return func(c *splitConfig) { c.maxParts = n }
+}
+
+// WithMaxPartLength sets the number of characters of a part including its marker, MaxMessageLength by default
+func WithMaxPartLength(n int) SplitOption {
+	return func(c *splitConfig) { c.maxPartLength = n }
+}
+
+// WithTruncatedLink sets the link in the footer of a message cut by WithMaxParts, such as the URL of the full log
+func WithTruncatedLink(link string) SplitOption {
+	return func(c *splitConfig) { c.link = link }
+}
+
+// WithPartInterval sets the wait between sending two parts, 1 second by default, so that they arrive in order
+func WithPartInterval(d time.Duration) SplitOption {
+	return func(c *splitConfig) { c.interval = d }
+}
+
+// WithSendOptions sets the options of SendMessage for every part sent by SendSplit. The key of WithIdempotencyKey
+// is suffixed with the number of the part, as in key/2, so that a retry of SendSplit sends only the parts not sent yet.
+// A message sent in a single part keeps the key as is.
+func WithSendOptions(opts ...SendOption) SplitOption {
+	return func(c *splitConfig) { c.sendOptions = append(c.sendOptions, opts...) }
+}
+
+// ErrPartNotSent is the error of the parts not sent by SendSplit after a part failed
+var ErrPartNotSent = errors.New("not sent after a previous part failed")
+
+// SplitResult is the outcome of sending a part of a message
+type SplitResult struct {
+	// Part is the number of the part from 1
+	Part     int
+	Message  string
+	Response *v1.SendNotificationMessageResponse
+	Err      error
+}
+
+// SplitMessage splits a message over the part length into parts marked (1/3), (2/3) and so on.
+// A part ends at the last line break that fits, which is dropped since the parts are separate messages,
+// or is cut between two characters of a line too long. A message that fits is returned as is, without a marker.
+func SplitMessage(message string, opts ...SplitOption) ([]string, error) {
+	return newSplitConfig(opts).split(message)
+}
+
+func (c splitConfig) split(message string) ([]string, error) {
+	if c.maxParts < 1 {
+		return nil, fmt.Errorf("invalid number of parts %d", c.maxParts)
+	}
+	if utf8.RuneCountInString(message) <= c.maxPartLength {
+		return []string{message}, nil
+	}
+	footer := "\n(truncated)"
+	if c.link != "" {
+		footer = "\n(truncated, see " + c.link + ")"
+	}
+	// the marker is reserved for the largest number of parts, so that the parts do not depend on their count
+	budget := c.maxPartLength - utf8.RuneCountInString(fmt.Sprintf("(%d/%d) ", c.maxParts, c.maxParts))
+	if budget < 1 {
+		return nil, fmt.Errorf("part length %d leaves no room for the text of a part", c.maxPartLength)
+	}
+
+	var bodies []string
+	for rest := message; rest != ""; {
+		var body string
+		if len(bodies) == c.maxParts-1 && utf8.RuneCountInString(rest) > budget {
+			if budget <= utf8.RuneCountInString(footer) {
+				return nil, fmt.Errorf("part length %d leaves no room for the text of a part before the footer %q", c.maxPartLength, footer)
+			}
+			body, _ = cutPart(rest, budget-utf8.RuneCountInString(footer))
+			bodies = append(bodies, body+footer)
+			break
+		}
+		body, rest = cutPart(rest, budget)
+		bodies = append(bodies, body)
+	}
+	parts := make([]string, len(bodies))
+	for i, body := range bodies {
+		parts[i] = fmt.Sprintf("(%d/%d) %s", i+1, len(bodies), body)
+	}
+	return parts, nil
+}
+
+// cutPart cuts text at the last line break within n characters, or at n characters when there is none.
+// The line break at the cut is in neither part.
+func cutPart(text string, n int) (part, rest string) {
+	if utf8.RuneCountInString(text) <= n {
+		return text, ""
+	}
+	end := 0
+	for range n {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+	if i := strings.LastIndexByte(text[:end], '\n'); i > 0 {
+		return text[:i], text[i+1:]
+	}
+	return text[:end], text[end:]
+}
+
+// SendSplit splits the message of request with SplitMessage and sends the parts to the group in order,
+// waiting between them, with the options of WithSendOptions. The first part failing stops the sending and the parts left
+// get ErrPartNotSent. A part already sent with its idempotency key gets a *DuplicateMessageError but does not stop the sending.
+// It returns a result per part and the error of the part that failed.
+func SendSplit(ctx context.Context, api GroupAPI, groupID string, request v1.SendNotificationMessageRequest, opts ...SplitOption) ([]SplitResult, error) {
+	const methodName = "SendSplit"
+	config := newSplitConfig(opts)
+	parts, err := config.split(request.Message)
+	if err != nil {
+		return nil, NewError(methodName, err)
+	}
+	results := make([]SplitResult, len(parts))
+	var failed error
+	for i, part := range parts {
+		results[i] = SplitResult{Part: i + 1, Message: part}
+		if failed != nil {
+			results[i].Err = ErrPartNotSent
+			continue
+		}
+		if i > 0 {
+			if err := sleepContext(ctx, config.interval); err != nil {
+				results[i].Err, failed = err, err
+				continue
+			}
+		}
+		sendOptions := config.sendOptions
+		if len(parts) > 1 {
+			sendOptions = append(sendOptions[:len(sendOptions):len(sendOptions)], withPartKey(i+1))
+		}
+		results[i].Response, results[i].Err = SendMessage(ctx, api, groupID, v1.SendNotificationMessageRequest{Message: part}, sendOptions...)
+		var dup *DuplicateMessageError
+		if !errors.As(results[i].Err, &dup) || dup.InFlight {
+			failed = results[i].Err
+		}
+	}
+	if failed != nil {
+		return results, NewError(methodName, failed)
+	}
+	return results, nil
+}
+
+// withPartKey suffixes the idempotency key, if any, with the number of the part
+func withPartKey(part int) SendOption {
+	return func(c *sendConfig) {
+		if c.key != "" {
+			c.key = fmt.Sprintf("%s/%d", c.key, part)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sacloud/saclient-go"
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationmock"
+	"github.com/stretchr/testify/require"
+)
+
+func numberedLines(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %02d", i+1)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestSplitMessage(t *testing.T) {
+	assert := require.New(t)
+
+	parts, err := simplenotification.SplitMessage("short")
+	assert.NoError(err)
+	assert.Equal([]string{"short"}, parts)
+
+	parts, err = simplenotification.SplitMessage("line one\nline two\nline three", simplenotification.WithMaxPartLength(20))
+	assert.NoError(err)
+	assert.Equal([]string{"(1/3) line one", "(2/3) line two", "(3/3) line three"}, parts)
+
+	parts, err = simplenotification.SplitMessage("あいうえおかきくけこさしすせそたちつてとなにぬねの", simplenotification.WithMaxPartLength(20))
+	assert.NoError(err)
+	assert.Equal([]string{"(1/3) あいうえおかきくけこさし", "(2/3) すせそたちつてとなにぬね", "(3/3) の"}, parts)
+
+	parts, err = simplenotification.SplitMessage(numberedLines(20), simplenotification.WithMaxPartLength(60),
+		simplenotification.WithMaxParts(2), simplenotification.WithTruncatedLink("https://l.example"))
+	assert.NoError(err)
+	assert.Equal([]string{
+		"(1/2) " + numberedLines(6),
+		"(2/2) line 07\nline 08\n(truncated, see https://l.example)",
+	}, parts)
+
+	parts, err = simplenotification.SplitMessage(strings.Repeat("x", 100), simplenotification.WithMaxPartLength(30), simplenotification.WithMaxParts(2))
+	assert.NoError(err)
+	assert.Equal("(2/2) "+strings.Repeat("x", 12)+"\n(truncated)", parts[1])
+
+	long := strings.Repeat(numberedLines(300)+"\n"+strings.Repeat("長", 3000)+"\n", 4)
+	parts, err = simplenotification.SplitMessage(long)
+	assert.NoError(err)
+	assert.Len(parts, 10)
+	assert.True(strings.HasSuffix(parts[9], "\n(truncated)"))
+	for _, part := range parts {
+		assert.True(utf8.ValidString(part))
+		assert.LessOrEqual(utf8.RuneCountInString(part), simplenotification.MaxMessageLength)
+	}
+
+	_, err = simplenotification.SplitMessage(long, simplenotification.WithMaxParts(0))
+	assert.Error(err)
+	_, err = simplenotification.SplitMessage(long, simplenotification.WithMaxPartLength(8))
+	assert.Error(err)
+	_, err = simplenotification.SplitMessage(long, simplenotification.WithMaxPartLength(20))
+	assert.ErrorContains(err, "before the footer")
+}
+
+func TestSendSplit(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	groupAPI := simplenotification.NewGroupOp(client)
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	group := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("ops", dest))
+	})
+	request := v1.SendNotificationMessageRequest{Message: numberedLines(600)}
+
+	results, err := simplenotification.SendSplit(ctx, groupAPI, group, request, simplenotification.WithPartInterval(0))
+	assert.NoError(err)
+	assert.Len(results, 3)
+	for i, result := range results {
+		assert.NoError(result.Err)
+		assert.Equal(i+1, result.Part)
+		assert.NotNil(result.Response)
+		assert.True(strings.HasPrefix(result.Message, fmt.Sprintf("(%d/3) ", i+1)))
+	}
+	histories, err := simplenotification.NewHistoryOp(client).List(ctx)
+	assert.NoError(err)
+	var sent []string
+	for _, history := range histories.NotificationHistories {
+		sent = append(sent, history.Message.Body)
+	}
+	slices.Reverse(sent)
+	assert.Equal([]string{results[0].Message, results[1].Message, results[2].Message}, sent)
+
+	results, err = simplenotification.SendSplit(ctx, groupAPI, "999999999999", request, simplenotification.WithPartInterval(0))
+	assert.True(saclient.IsNotFoundError(err))
+	assert.Len(results, 3)
+	assert.True(saclient.IsNotFoundError(results[0].Err))
+	assert.ErrorIs(results[1].Err, simplenotification.ErrPartNotSent)
+	assert.ErrorIs(results[2].Err, simplenotification.ErrPartNotSent)
+}
+
+func TestSendSplit_IdempotencyKey(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	groupAPI := simplenotification.NewGroupOp(client)
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	group := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("ops", dest))
+	})
+	history := simplenotification.NewHistoryOp(client)
+	store := simplenotification.NewMemoryIdempotencyStore(time.Hour)
+	request := v1.SendNotificationMessageRequest{Message: numberedLines(600)}
+	opts := []simplenotification.SplitOption{
+		simplenotification.WithPartInterval(0),
+		simplenotification.WithSendOptions(simplenotification.WithIdempotencyKey("deploy-42", store, history)),
+	}
+
+	// the second part fails, so the third one is not sent
+	failing := true
+	flaky := &simplenotificationmock.GroupAPI{
+		SendMessageFunc: func(ctx context.Context, id string, request v1.SendNotificationMessageRequest) (*v1.SendNotificationMessageResponse, error) {
+			if failing && strings.HasPrefix(request.Message, "(2/3) ") {
+				return nil, errors.New("unavailable")
+			}
+			return groupAPI.SendMessage(ctx, id, request)
+		},
+	}
+	results, err := simplenotification.SendSplit(ctx, flaky, group, request, opts...)
+	assert.ErrorContains(err, "unavailable")
+	assert.NoError(results[0].Err)
+	assert.ErrorIs(results[2].Err, simplenotification.ErrPartNotSent)
+	for part, sent := range map[string]bool{"deploy-42/1": true, "deploy-42/2": false, "deploy-42/3": false} {
+		record, ok, err := store.Load(ctx, part)
+		assert.NoError(err)
+		assert.Equal(sent, ok && record.Confirmed, part)
+	}
+
+	// a retry sends only the parts not sent yet
+	failing = false
+	results, err = simplenotification.SendSplit(ctx, flaky, group, request, opts...)
+	assert.NoError(err)
+	var dup *simplenotification.DuplicateMessageError
+	assert.ErrorAs(results[0].Err, &dup)
+	assert.Equal("deploy-42/1", dup.Key)
+	assert.NoError(results[1].Err)
+	assert.NoError(results[2].Err)
+	histories, err := history.List(ctx)
+	assert.NoError(err)
+	assert.Len(histories.NotificationHistories, 3)
+
+	// a message in a single part keeps the key
+	_, err = simplenotification.SendSplit(ctx, groupAPI, group, v1.SendNotificationMessageRequest{Message: "deployed"}, opts...)
+	assert.NoError(err)
+	_, ok, err := store.Load(ctx, "deploy-42")
+	assert.NoError(err)
+	assert.True(ok)
+}
@@ -0,0 +1,154 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// MaxMessageLength is the maximum number of characters of SendNotificationMessageRequest.Message
+const MaxMessageLength = 2048
+
+// JST is the Japan time zone of the API, fixed so that it works without the tz database
+var JST = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// jstLayout is the layout of the jst template function
+const jstLayout = "2006-01-02 15:04:05 JST"
+
+// MessageTooLongError reports a rendered message over MaxMessageLength, which the API would reject
+type MessageTooLongError struct {
+	Template string
+	Length   int
+}
+
+func (e *MessageTooLongError) Error() string {
+	return fmt.Sprintf("message of template %q is %d characters, over the limit of %d", e.Template, e.Length, MaxMessageLength)
+}
+
+// MessageBuilder renders messages from named text/template templates and sends them to groups.
+//
+// Besides the builtin functions of text/template, the templates can use:
+//
+//	jst TIME                  the time in JST as 2006-01-02 15:04:05 JST
+//	jstFormat LAYOUT TIME     the time in JST with a time.Format layout
+//	truncate N TEXT           the text cut to N characters, ending with … when it was cut
+//	labels MAP                the map[string]string sorted by key as key=value, key=value
+//
+// A key missing from a map is an error instead of rendering "<no value>".
+type MessageBuilder struct {
+	api       GroupAPI
+	mu        sync.RWMutex
+	templates map[string]*template.Template
+}
+
+// NewMessageBuilder creates a MessageBuilder without templates, sending with api
+func NewMessageBuilder(api GroupAPI) *MessageBuilder {
+	return &MessageBuilder{api: api, templates: map[string]*template.Template{}}
+}
+
+// Register parses text as the template name, replacing any template already registered with the name
+func (b *MessageBuilder) Register(name, text string) error {
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.templates[name] = tmpl
+	return nil
+}
+
+// MustRegister is like Register but panics when text does not parse, for templates defined in the code
+func (b *MessageBuilder) MustRegister(name, text string) *MessageBuilder {
+	if err := b.Register(name, text); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Names returns the names of the registered templates, sorted
+func (b *MessageBuilder) Names() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return slices.Sorted(maps.Keys(b.templates))
+}
+
+// Render executes the template name with data. A message over MaxMessageLength is a *MessageTooLongError.
+func (b *MessageBuilder) Render(name string, data any) (string, error) {
+	b.mu.RLock()
+	tmpl, ok := b.templates[name]
+	b.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("unknown message template %q", name)
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	message := buf.String()
+	if n := utf8.RuneCountInString(message); n > MaxMessageLength {
+		return "", &MessageTooLongError{Template: name, Length: n}
+	}
+	return message, nil
+}
+
+// SendTemplate renders the template name with data and sends the message to the group.
+// Nothing is sent when the template fails to render.
+func (b *MessageBuilder) SendTemplate(ctx context.Context, groupID, name string, data any, opts ...SendOption) (*v1.SendNotificationMessageResponse, error) {
+	message, err := b.Render(name, data)
+	if err != nil {
+		return nil, NewError("MessageBuilder.SendTemplate", err)
+	}
+	return SendMessage(ctx, b.api, groupID, v1.SendNotificationMessageRequest{Message: message}, opts...)
+}
+
+var templateFuncs = template.FuncMap{
+	"jst": func(t time.Time) string {
+		return t.In(JST).Format(jstLayout)
+	},
+	"jstFormat": func(layout string, t time.Time) string {
+		return t.In(JST).Format(layout)
+	},
+	"truncate": truncate,
+	"labels": func(labels map[string]string) string {
+		pairs := make([]string, 0, len(labels))
+		for _, key := range slices.Sorted(maps.Keys(labels)) {
+			pairs = append(pairs, key+"="+labels[key])
+		}
+		return strings.Join(pairs, ", ")
+	},
+}
+
+// truncate cuts s to n characters, the last one being … when it was cut
+func truncate(n int, s string) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	if n <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
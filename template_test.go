@@ -0,0 +1,88 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageBuilder_Render(t *testing.T) {
+	assert := require.New(t)
+	builder := simplenotification.NewMessageBuilder(nil).
+		MustRegister("deploy-failed", `[{{.Service}}] deploy failed at {{jst .At}} ({{.At | jstFormat "15:04"}})
+{{truncate 10 .Reason}}
+{{labels .Labels}}`).
+		MustRegister("short", `{{truncate 3 .}}`)
+	assert.Equal([]string{"deploy-failed", "short"}, builder.Names())
+
+	message, err := builder.Render("deploy-failed", map[string]any{
+		"Service": "api",
+		"At":      time.Date(2026, 3, 31, 15, 30, 0, 0, time.UTC),
+		"Reason":  "health check timed out after 300s",
+		"Labels":  map[string]string{"env": "prod", "region": "is1a"},
+	})
+	assert.NoError(err)
+	assert.Equal(`[api] deploy failed at 2026-04-01 00:30:00 JST (00:30)
+health ch…
+env=prod, region=is1a`, message)
+
+	for in, want := range map[string]string{"ab": "ab", "abc": "abc", "abcd": "ab…", "あいうえ": "あい…"} {
+		message, err := builder.Render("short", in)
+		assert.NoError(err)
+		assert.Equal(want, message)
+	}
+
+	_, err = builder.Render("deploy-failed", map[string]any{"Service": "api"})
+	assert.ErrorContains(err, `map has no entry for key "At"`)
+	_, err = builder.Render("missing", nil)
+	assert.EqualError(err, `unknown message template "missing"`)
+	assert.Error(builder.Register("broken", "{{.Service"))
+}
+
+func TestMessageBuilder_SendTemplate(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	groupAPI := simplenotification.NewGroupOp(client)
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return simplenotification.NewDestinationOp(client).Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	group := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("ops", dest))
+	})
+	builder := simplenotification.NewMessageBuilder(groupAPI).MustRegister("echo", "{{.}}")
+
+	_, err := builder.SendTemplate(ctx, group, "echo", "hello")
+	assert.NoError(err)
+
+	_, err = builder.SendTemplate(ctx, group, "echo", strings.Repeat("あ", simplenotification.MaxMessageLength))
+	assert.NoError(err)
+
+	_, err = builder.SendTemplate(ctx, group, "echo", strings.Repeat("a", simplenotification.MaxMessageLength+1))
+	var tooLong *simplenotification.MessageTooLongError
+	assert.True(errors.As(err, &tooLong))
+	assert.Equal(&simplenotification.MessageTooLongError{Template: "echo", Length: simplenotification.MaxMessageLength + 1}, tooLong)
+
+	histories, err := simplenotification.NewHistoryOp(client).List(ctx)
+	assert.NoError(err)
+	assert.Len(histories.NotificationHistories, 2)
+}
@@ -0,0 +1,191 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// ExternalIDTagPrefix prefixes the tag holding the external ID given by WithExternalID
+const ExternalIDTagPrefix = "external-id="
+
+// UpsertAction is the action taken by CreateOrGet and Upsert
+type UpsertAction string
+
+const (
+	UpsertCreated   UpsertAction = "created"
+	UpsertUpdated   UpsertAction = "updated"
+	UpsertUnchanged UpsertAction = "unchanged"
+)
+
+// UpsertResult is the resource returned by CreateOrGet and Upsert with the action taken
+type UpsertResult struct {
+	Action            UpsertAction
+	CommonServiceItem v1.CommonServiceItem
+}
+
+// UpsertOption configures CreateOrGet and Upsert
+type UpsertOption func(*upsertConfig)
+
+type upsertConfig struct {
+	externalID string
+}
+
+// WithExternalID identifies the resource by the tag ExternalIDTagPrefix+id instead of its name,
+// so that it can be renamed. The tag is added to the request.
+func WithExternalID(id string) UpsertOption {
+	return func(c *upsertConfig) { c.externalID = id }
+}
+
+// CommonServiceItemAPI is the part of DestinationAPI, GroupAPI and RoutingAPI common to every kind of resource
+type CommonServiceItemAPI interface {
+	List(ctx context.Context) (*v1.ListCommonServiceItemsResponse, error)
+	Create(ctx context.Context, request v1.PostCommonServiceItemRequest) (*v1.CreateCommonServiceItemCreated, error)
+	Read(ctx context.Context, id string) (*v1.GetCommonServiceItemOK, error)
+	Update(ctx context.Context, id string, request v1.PutCommonServiceItemRequest) (*v1.UpdateCommonServiceItemOK, error)
+	Delete(ctx context.Context, id string) error
+}
+
+var (
+	_ CommonServiceItemAPI = (DestinationAPI)(nil)
+	_ CommonServiceItemAPI = (GroupAPI)(nil)
+	_ CommonServiceItemAPI = (RoutingAPI)(nil)
+)
+
+// CreateOrGet creates the resource with api unless one with the same name, or the external ID given by WithExternalID,
+// exists, in which case it is returned unchanged
+func CreateOrGet(ctx context.Context, api CommonServiceItemAPI, request v1.PostCommonServiceItemRequest, opts ...UpsertOption) (*UpsertResult, error) {
+	return upsert(ctx, api, "CreateOrGet", request, false, opts)
+}
+
+// Upsert creates the resource with api unless one with the same name, or the external ID given by WithExternalID,
+// exists, in which case it is updated to match the request if needed
+func Upsert(ctx context.Context, api CommonServiceItemAPI, request v1.PostCommonServiceItemRequest, opts ...UpsertOption) (*UpsertResult, error) {
+	return upsert(ctx, api, "Upsert", request, true, opts)
+}
+
+// upsert creates the resource of the request unless one with the same name or external ID exists,
+// which is updated to match the request when update is set
+func upsert(ctx context.Context, api CommonServiceItemAPI, methodName string, request v1.PostCommonServiceItemRequest, update bool, opts []UpsertOption) (*UpsertResult, error) {
+	var config upsertConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	item := &request.CommonServiceItem
+	key := fmt.Sprintf("name %q", item.Name)
+	match := func(existing v1.CommonServiceItem) bool { return existing.Name == item.Name }
+	if config.externalID != "" {
+		tag := ExternalIDTagPrefix + config.externalID
+		if !slices.Contains(item.Tags, tag) {
+			item.Tags = append(slices.Clone(item.Tags), tag)
+		}
+		key = fmt.Sprintf("external ID %q", config.externalID)
+		match = func(existing v1.CommonServiceItem) bool { return slices.Contains(existing.Tags, tag) }
+	}
+
+	list, err := api.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var found []v1.CommonServiceItem
+	for _, existing := range list.CommonServiceItems {
+		if !IsUnknown(existing) && match(existing) {
+			found = append(found, existing)
+		}
+	}
+
+	switch {
+	case len(found) > 1:
+		ids := make([]string, len(found))
+		for i, existing := range found {
+			ids[i] = existing.ID
+		}
+		return nil, NewError(methodName, fmt.Errorf("%d resources match the %s: %v", len(found), key, ids))
+	case len(found) == 0:
+		res, err := api.Create(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		return &UpsertResult{Action: UpsertCreated, CommonServiceItem: res.CommonServiceItem}, nil
+	case !update || matches(found[0], request):
+		return &UpsertResult{Action: UpsertUnchanged, CommonServiceItem: found[0]}, nil
+	}
+
+	existing := found[0]
+	settings := v1.PutCommonServiceItemRequestCommonServiceItemSettings{
+		CommonServiceItemDestinationSettings: item.Settings.CommonServiceItemDestinationSettings,
+		CommonServiceItemGroupSettings:       item.Settings.CommonServiceItemGroupSettings,
+		CommonServiceItemRoutingSettings:     item.Settings.CommonServiceItemRoutingSettings,
+	}
+	// the priority is changed by Reorder only
+	settings.CommonServiceItemRoutingSettings.PriorityRank = existing.Settings.CommonServiceItemRoutingSettings.PriorityRank
+	res, err := api.Update(ctx, existing.ID, v1.PutCommonServiceItemRequest{
+		CommonServiceItem: v1.PutCommonServiceItemRequestCommonServiceItem{
+			Name:        item.Name,
+			Description: item.Description,
+			Tags:        item.Tags,
+			Icon:        item.Icon,
+			Settings:    v1.NewOptPutCommonServiceItemRequestCommonServiceItemSettings(settings),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &UpsertResult{Action: UpsertUpdated, CommonServiceItem: res.CommonServiceItem}, nil
+}
+
+// matches reports whether the existing resource has the name, description, tags and settings of the request.
+// The icon and the priority of a routing are not compared.
+func matches(existing v1.CommonServiceItem, request v1.PostCommonServiceItemRequest) bool {
+	item := request.CommonServiceItem
+	if existing.Name != item.Name || existing.Description != item.Description || !sameSet(existing.Tags, item.Tags) {
+		return false
+	}
+	have, want := existing.Settings, item.Settings
+	switch existing.Provider.Class {
+	case v1.CommonServiceItemProviderClassSaknoticedestination:
+		h, w := have.CommonServiceItemDestinationSettings, want.CommonServiceItemDestinationSettings
+		return h.Type == w.Type && h.Value == w.Value && h.Disabled.Or(false) == w.Disabled.Or(false)
+	case v1.CommonServiceItemProviderClassSaknoticegroup:
+		h, w := have.CommonServiceItemGroupSettings, want.CommonServiceItemGroupSettings
+		return sameSet(h.Destinations, w.Destinations) && h.Disabled.Or(false) == w.Disabled.Or(false)
+	case v1.CommonServiceItemProviderClassSaknoticerouting:
+		h, w := have.CommonServiceItemRoutingSettings, want.CommonServiceItemRoutingSettings
+		return h.SourceID == w.SourceID && h.TargetGroupID == w.TargetGroupID && sameSet(h.MatchLabels, w.MatchLabels)
+	}
+	return false
+}
+
+// sameSet reports whether a and b have the same elements regardless of the order
+func sameSet[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	count := make(map[T]int, len(a))
+	for _, v := range a {
+		count[v]++
+	}
+	for _, v := range b {
+		if count[v] == 0 {
+			return false
+		}
+		count[v]--
+	}
+	return true
+}
@@ -0,0 +1,151 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"testing"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOrGet(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+
+	created, err := simplenotification.CreateOrGet(ctx, destinationAPI, fakeDestination("oncall", "oncall@example.com"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertCreated, created.Action)
+
+	// an existing destination is returned as is, even when the request differs
+	got, err := simplenotification.CreateOrGet(ctx, destinationAPI, fakeDestination("oncall", "other@example.com"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertUnchanged, got.Action)
+	assert.Equal(created.CommonServiceItem.ID, got.CommonServiceItem.ID)
+	assert.Equal("oncall@example.com", got.CommonServiceItem.Settings.CommonServiceItemDestinationSettings.Value)
+
+	list, err := destinationAPI.List(ctx)
+	assert.NoError(err)
+	assert.Len(list.CommonServiceItems, 1)
+}
+
+func TestUpsert(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+
+	created, err := simplenotification.Upsert(ctx, destinationAPI, fakeDestination("oncall", "oncall@example.com"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertCreated, created.Action)
+
+	unchanged, err := simplenotification.Upsert(ctx, destinationAPI, fakeDestination("oncall", "oncall@example.com"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertUnchanged, unchanged.Action)
+	assert.Equal(created.CommonServiceItem.ID, unchanged.CommonServiceItem.ID)
+
+	updated, err := simplenotification.Upsert(ctx, destinationAPI, fakeDestination("oncall", "other@example.com"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertUpdated, updated.Action)
+	assert.Equal(created.CommonServiceItem.ID, updated.CommonServiceItem.ID)
+	assert.Equal("other@example.com", updated.CommonServiceItem.Settings.CommonServiceItemDestinationSettings.Value)
+
+	// names are not unique, so duplicates are reported instead of picking one
+	_, err = destinationAPI.Create(ctx, fakeDestination("oncall", "third@example.com"))
+	assert.NoError(err)
+	_, err = simplenotification.Upsert(ctx, destinationAPI, fakeDestination("oncall", "oncall@example.com"))
+	assert.ErrorContains(err, `Upsert: 2 resources match the name "oncall"`)
+}
+
+func TestUpsert_ExternalID(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+	alice := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	bob := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("bob", "bob@example.com"))
+	})
+
+	created, err := simplenotification.Upsert(ctx, groupAPI, fakeGroup("oncall", alice, bob), simplenotification.WithExternalID("team-1"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertCreated, created.Action)
+	assert.Contains(created.CommonServiceItem.Tags, simplenotification.ExternalIDTagPrefix+"team-1")
+
+	// the order of the destinations does not matter
+	unchanged, err := simplenotification.Upsert(ctx, groupAPI, fakeGroup("oncall", bob, alice), simplenotification.WithExternalID("team-1"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertUnchanged, unchanged.Action)
+
+	// the external ID finds the group after a rename
+	renamed, err := simplenotification.Upsert(ctx, groupAPI, fakeGroup("primary", alice), simplenotification.WithExternalID("team-1"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertUpdated, renamed.Action)
+	assert.Equal(created.CommonServiceItem.ID, renamed.CommonServiceItem.ID)
+	assert.Equal("primary", renamed.CommonServiceItem.Name)
+	assert.Equal([]string{alice}, renamed.CommonServiceItem.Settings.CommonServiceItemGroupSettings.Destinations)
+
+	got, err := simplenotification.CreateOrGet(ctx, groupAPI, fakeGroup("oncall", alice), simplenotification.WithExternalID("team-1"))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertUnchanged, got.Action)
+	assert.Equal("primary", got.CommonServiceItem.Name)
+
+	// duplicates report the external ID they share, not the name of the request
+	duplicate := fakeGroup("secondary", bob)
+	duplicate.CommonServiceItem.Tags = []string{simplenotification.ExternalIDTagPrefix + "team-1"}
+	_, err = groupAPI.Create(ctx, duplicate)
+	assert.NoError(err)
+	_, err = simplenotification.Upsert(ctx, groupAPI, fakeGroup("oncall", alice), simplenotification.WithExternalID("team-1"))
+	assert.ErrorContains(err, `Upsert: 2 resources match the external ID "team-1"`)
+}
+
+func TestUpsert_Routing(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	_, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	groupAPI := simplenotification.NewGroupOp(client)
+	routingAPI := simplenotification.NewRoutingOp(client)
+	dest := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+	ops := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("ops", dest))
+	})
+	dev := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return groupAPI.Create(ctx, fakeGroup("dev", dest))
+	})
+
+	created, err := simplenotification.Upsert(ctx, routingAPI, fakeRouting("monitor", "1", ops))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertCreated, created.Action)
+	rank := created.CommonServiceItem.Settings.CommonServiceItemRoutingSettings.PriorityRank
+
+	updated, err := simplenotification.Upsert(ctx, routingAPI, fakeRouting("monitor", "1", dev))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertUpdated, updated.Action)
+	assert.Equal(dev, updated.CommonServiceItem.Settings.CommonServiceItemRoutingSettings.TargetGroupID)
+	assert.Equal(rank, updated.CommonServiceItem.Settings.CommonServiceItemRoutingSettings.PriorityRank)
+
+	unchanged, err := simplenotification.Upsert(ctx, routingAPI, fakeRouting("monitor", "1", dev))
+	assert.NoError(err)
+	assert.Equal(simplenotification.UpsertUnchanged, unchanged.Action)
+}
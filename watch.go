@@ -0,0 +1,215 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+)
+
+// WatchEventType is the kind of change reported by Watch
+type WatchEventType string
+
+const (
+	WatchCreated WatchEventType = "created"
+	WatchUpdated WatchEventType = "updated"
+	WatchDeleted WatchEventType = "deleted"
+)
+
+// WatchEvent is a change of a destination, group or routing found by Watch
+type WatchEvent struct {
+	Type WatchEventType
+	// Item is the resource after the change, or the last state seen of a deleted one
+	Item v1.CommonServiceItem
+	// Previous is the resource before an update
+	Previous *v1.CommonServiceItem
+	// Changes are the fields changed by an update
+	Changes []FieldChange
+	// Baseline is the state including this event and the ones before it.
+	// Save it after handling the event and pass it to WithWatchBaseline to resume from there.
+	Baseline *Inventory
+}
+
+// FieldChange is a field changed by an update
+type FieldChange struct {
+	// Field is the path of the field in the JSON of the resource, such as "Settings.Destinations"
+	Field string
+	// Old and New are the JSON values of the field, nil when it is absent
+	Old, New any
+}
+
+// WatchOption configures Watch
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	baseline *Inventory
+}
+
+// WithWatchBaseline makes Watch report the changes since the baseline, usually the Baseline of the last event handled.
+// Without it the first poll is the baseline and changes made before it are not reported.
+func WithWatchBaseline(baseline *Inventory) WatchOption {
+	return func(c *watchConfig) { c.baseline = baseline }
+}
+
+// Watch polls the destinations, groups and routings every interval and yields their changes.
+// A resource is updated when its ModifiedAt changes. A failed poll yields its error and the watch goes on
+// until the context is done or the loop breaks.
+// An interval of zero or less yields an error and ends the watch.
+func (o *InventoryOp) Watch(ctx context.Context, interval time.Duration, opts ...WatchOption) iter.Seq2[*WatchEvent, error] {
+	return WatchInventory(ctx, o, interval, opts...)
+}
+
+// WatchInventory implements Watch by polling api.Inventory, for wrappers of an InventoryAPI
+func WatchInventory(ctx context.Context, api InventoryAPI, interval time.Duration, opts ...WatchOption) iter.Seq2[*WatchEvent, error] {
+	var config watchConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return func(yield func(*WatchEvent, error) bool) {
+		if interval <= 0 {
+			yield(nil, NewError("WatchInventory", fmt.Errorf("interval must be positive, got %s", interval)))
+			return
+		}
+		baseline := config.baseline
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			inv, err := api.Inventory(ctx)
+			switch {
+			case ctx.Err() != nil:
+				return
+			case err != nil:
+				if !yield(nil, err) {
+					return
+				}
+			case baseline == nil:
+				baseline = inv
+			default:
+				for event := range diffInventory(baseline, inv) {
+					baseline = event.Baseline
+					if !yield(event, nil) {
+						return
+					}
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// diffInventory yields the changes from old to current, destinations first, then groups and routings
+func diffInventory(old, current *Inventory) iter.Seq[*WatchEvent] {
+	return func(yield func(*WatchEvent) bool) {
+		state := [][]v1.CommonServiceItem{
+			slices.Clone(old.Destinations),
+			slices.Clone(old.Groups),
+			slices.Clone(old.Routings),
+		}
+		emit := func(event *WatchEvent) bool {
+			event.Baseline = NewInventory(slices.Clone(state[0]), slices.Clone(state[1]), slices.Clone(state[2]))
+			return yield(event)
+		}
+		for i, items := range [][]v1.CommonServiceItem{current.Destinations, current.Groups, current.Routings} {
+			seen := make(map[string]bool, len(items))
+			for _, item := range items {
+				seen[item.ID] = true
+				j := slices.IndexFunc(state[i], func(existing v1.CommonServiceItem) bool { return existing.ID == item.ID })
+				switch {
+				case j < 0:
+					state[i] = append(state[i], item)
+					if !emit(&WatchEvent{Type: WatchCreated, Item: item}) {
+						return
+					}
+				case !state[i][j].ModifiedAt.Equal(item.ModifiedAt):
+					previous := state[i][j]
+					state[i][j] = item
+					if !emit(&WatchEvent{Type: WatchUpdated, Item: item, Previous: &previous, Changes: diffItem(previous, item)}) {
+						return
+					}
+				}
+			}
+			for _, item := range slices.Clone(state[i]) {
+				if seen[item.ID] {
+					continue
+				}
+				state[i] = slices.DeleteFunc(state[i], func(existing v1.CommonServiceItem) bool { return existing.ID == item.ID })
+				if !emit(&WatchEvent{Type: WatchDeleted, Item: item}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// diffItem compares the JSON of the resources field by field, sorted by path.
+// Index and ModifiedAt are left out as they change without a change of the resource.
+func diffItem(old, current v1.CommonServiceItem) []FieldChange {
+	oldFields, currentFields := flattenItem(old), flattenItem(current)
+	var changes []FieldChange
+	for field, value := range currentFields {
+		if !reflect.DeepEqual(oldFields[field], value) {
+			changes = append(changes, FieldChange{Field: field, Old: oldFields[field], New: value})
+		}
+	}
+	for field, value := range oldFields {
+		if _, ok := currentFields[field]; !ok {
+			changes = append(changes, FieldChange{Field: field, Old: value})
+		}
+	}
+	slices.SortFunc(changes, func(a, b FieldChange) int { return strings.Compare(a.Field, b.Field) })
+	return changes
+}
+
+func flattenItem(item v1.CommonServiceItem) map[string]any {
+	fields := map[string]any{}
+	data, err := json.Marshal(&item)
+	if err != nil {
+		return fields
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fields
+	}
+	delete(doc, "Index")
+	delete(doc, "ModifiedAt")
+	var flatten func(prefix string, value any)
+	flatten = func(prefix string, value any) {
+		object, ok := value.(map[string]any)
+		if !ok {
+			fields[prefix] = value
+			return
+		}
+		for key, v := range object {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			flatten(key, v)
+		}
+	}
+	flatten("", doc)
+	return fields
+}
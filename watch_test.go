@@ -0,0 +1,166 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	simplenotification "github.com/sacloud/simple-notification-api-go"
+	v1 "github.com/sacloud/simple-notification-api-go/apis/v1"
+	"github.com/sacloud/simple-notification-api-go/simplenotificationmock"
+	"github.com/stretchr/testify/require"
+)
+
+func watchItem(class v1.CommonServiceItemProviderClass, id, name string, modified int) v1.CommonServiceItem {
+	return v1.CommonServiceItem{
+		ID:         id,
+		Name:       name,
+		Tags:       []string{},
+		ModifiedAt: time.Date(2026, 4, 1, 9, modified, 0, 0, time.UTC),
+		Provider:   v1.CommonServiceItemProvider{Class: class},
+		Settings: v1.CommonServiceItemSettings{
+			Type:                                 v1.CommonServiceItemDestinationSettingsCommonServiceItemSettings,
+			CommonServiceItemDestinationSettings: v1.CommonServiceItemDestinationSettings{Type: v1.CommonServiceItemDestinationSettingsTypeEmail, Value: name + "@example.com"},
+		},
+	}
+}
+
+// scriptedInventory returns the inventories in turn, then the last one
+func scriptedInventory(inventories ...*simplenotification.Inventory) *simplenotificationmock.InventoryAPI {
+	polls := 0
+	return &simplenotificationmock.InventoryAPI{
+		InventoryFunc: func(ctx context.Context) (*simplenotification.Inventory, error) {
+			inv := inventories[min(polls, len(inventories)-1)]
+			polls++
+			if inv == nil {
+				return nil, errors.New("unavailable")
+			}
+			return inv, nil
+		},
+	}
+}
+
+func TestWatch(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	const dest = v1.CommonServiceItemProviderClassSaknoticedestination
+	alice, bob, carol := watchItem(dest, "1", "alice", 0), watchItem(dest, "2", "bob", 0), watchItem(dest, "3", "carol", 0)
+	renamed := watchItem(dest, "1", "alicia", 5)
+	touched := watchItem(dest, "2", "bob", 0)
+	touched.Index = v1.NewOptInt(7)
+
+	api := scriptedInventory(
+		simplenotification.NewInventory([]v1.CommonServiceItem{alice, bob}, nil, nil),
+		nil,
+		simplenotification.NewInventory([]v1.CommonServiceItem{renamed, touched, carol}, nil, nil),
+		simplenotification.NewInventory([]v1.CommonServiceItem{renamed, carol}, nil, nil),
+	)
+	var events []*simplenotification.WatchEvent
+	var errs []error
+	for event, err := range api.Watch(ctx, time.Millisecond) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		events = append(events, event)
+		if len(events) == 3 {
+			break
+		}
+	}
+	assert.Len(errs, 1)
+	assert.Len(events, 3)
+
+	// the first poll is the baseline, and a change of the index only is not an update
+	assert.Equal(simplenotification.WatchUpdated, events[0].Type)
+	assert.Equal("alicia", events[0].Item.Name)
+	assert.Equal("alice", events[0].Previous.Name)
+	assert.Equal([]simplenotification.FieldChange{
+		{Field: "Name", Old: "alice", New: "alicia"},
+		{Field: "Settings.Value", Old: "alice@example.com", New: "alicia@example.com"},
+	}, events[0].Changes)
+	assert.Equal(simplenotification.WatchCreated, events[1].Type)
+	assert.Equal("carol", events[1].Item.Name)
+	assert.Equal(simplenotification.WatchDeleted, events[2].Type)
+	assert.Equal("bob", events[2].Item.Name)
+
+	// each event carries the state up to it
+	baseline := events[1].Baseline
+	assert.Len(baseline.Destinations, 3)
+	item, ok := baseline.Destination("1")
+	assert.True(ok)
+	assert.Equal("alicia", item.Name)
+	assert.Len(events[2].Baseline.Destinations, 2)
+}
+
+func TestWatch_Baseline(t *testing.T) {
+	assert := require.New(t)
+	ctx := t.Context()
+	const group = v1.CommonServiceItemProviderClassSaknoticegroup
+	ops, dev := watchItem(group, "1", "ops", 0), watchItem(group, "2", "dev", 0)
+
+	// resume from a baseline saved as JSON before a restart
+	data, err := json.Marshal(simplenotification.NewInventory(nil, []v1.CommonServiceItem{ops}, nil))
+	assert.NoError(err)
+	var baseline simplenotification.Inventory
+	assert.NoError(json.Unmarshal(data, &baseline))
+	_, ok := baseline.Group("1")
+	assert.True(ok)
+
+	api := scriptedInventory(simplenotification.NewInventory(nil, []v1.CommonServiceItem{ops, dev}, nil))
+	for event, err := range api.Watch(ctx, time.Millisecond, simplenotification.WithWatchBaseline(&baseline)) {
+		assert.NoError(err)
+		assert.Equal(simplenotification.WatchCreated, event.Type)
+		assert.Equal("dev", event.Item.Name)
+		break
+	}
+}
+
+func TestInventoryOp_Watch(t *testing.T) {
+	assert := require.New(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	_, client := fakeSetup(t)
+	destinationAPI := simplenotification.NewDestinationOp(client)
+	alice := mustCreate(t, func() (*v1.CreateCommonServiceItemCreated, error) {
+		return destinationAPI.Create(ctx, fakeDestination("alice", "alice@example.com"))
+	})
+
+	// an empty baseline replays everything as created
+	empty := simplenotification.NewInventory(nil, nil, nil)
+	var created []string
+	for event, err := range simplenotification.NewInventoryOp(client).Watch(ctx, time.Millisecond, simplenotification.WithWatchBaseline(empty)) {
+		assert.NoError(err)
+		assert.Equal(simplenotification.WatchCreated, event.Type)
+		created = append(created, event.Item.ID)
+		cancel()
+	}
+	assert.Equal([]string{alice}, created)
+}
+
+func TestWatch_Interval(t *testing.T) {
+	assert := require.New(t)
+	_, client := fakeSetup(t)
+	var errs []error
+	for event, err := range simplenotification.NewInventoryOp(client).Watch(t.Context(), 0) {
+		assert.Nil(event)
+		errs = append(errs, err)
+	}
+	assert.Len(errs, 1)
+	assert.ErrorContains(errs[0], "WatchInventory: interval must be positive, got 0s")
+}
@@ -0,0 +1,137 @@
+// Copyright 2026- The sacloud/simple-notification-api-go Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simplenotification
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	ht "github.com/ogen-go/ogen/http"
+	"github.com/sacloud/saclient-go"
+)
+
+const (
+	defaultZoneRootURL = "https://secure.sakura.ad.jp/cloud/zone"
+	defaultZone        = "is1a"
+	apiPathInZone      = "api/cloud/1.1/"
+)
+
+// KnownZones are the zones accepted by WithZone, WithFallbackZones and SAKURA_ZONE
+var KnownZones = []string{"is1a", "is1b", "is1c", "tk1a", "tk1b", "tk1v"}
+
+// WithZone selects the zone of the API endpoint. It takes precedence over SAKURA_ZONE and the profile.
+func WithZone(zone string) ClientOption {
+	return func(c *clientConfig) { c.zone = zone }
+}
+
+// WithFallbackZones sets the zones tried in order when the endpoint of the primary zone is unreachable.
+// Notification resources are global, so any zone serves the same ones.
+func WithFallbackZones(zones ...string) ClientOption {
+	return func(c *clientConfig) { c.fallbackZones = zones }
+}
+
+func validateZone(zone string) error {
+	if !slices.Contains(KnownZones, zone) {
+		return fmt.Errorf("unknown zone %q: must be one of %s", zone, strings.Join(KnownZones, ", "))
+	}
+	return nil
+}
+
+// zoneAPIRootURLs resolves the API root URL of the primary zone followed by the ones of the fallback zones.
+// The zone is taken from WithZone, then SAKURA_ZONE or the profile, and the root URL from SAKURA_API_ROOT_URL or the profile.
+func (c *clientConfig) zoneAPIRootURLs(endpoint *saclient.EndpointConfig) ([]string, error) {
+	root := defaultZoneRootURL
+	zone := defaultZone
+	if endpoint != nil {
+		if endpoint.APIRootURL != "" {
+			root = endpoint.APIRootURL
+		}
+		if endpoint.Zone != "" {
+			zone = endpoint.Zone
+		}
+	}
+	if c.zone != "" {
+		zone = c.zone
+	}
+
+	zones := append([]string{zone}, c.fallbackZones...)
+	urls := make([]string, 0, len(zones))
+	for _, z := range zones {
+		if err := validateZone(z); err != nil {
+			return nil, err
+		}
+		urls = append(urls, strings.TrimSuffix(root, "/")+"/"+z+"/"+apiPathInZone)
+	}
+	return urls, nil
+}
+
+// failoverClient sends a request to the next API root URL when the one of the previous zone is unreachable
+type failoverClient struct {
+	client   ht.Client
+	rootURLs []string
+}
+
+func (c *failoverClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	for _, root := range c.rootURLs[1:] {
+		if !isUnreachable(err) || !isGlobalResource(req) {
+			break
+		}
+		next, rerr := rebaseRequest(req, c.rootURLs[0], root)
+		if rerr != nil {
+			break
+		}
+		resp, err = c.client.Do(next)
+	}
+	return resp, err
+}
+
+// isGlobalResource reports whether the request is for a resource served by every zone
+func isGlobalResource(req *http.Request) bool {
+	return strings.Contains(req.URL.Path, "/"+commonServiceItemPath)
+}
+
+// isUnreachable reports whether the request failed before it reached the server, so that it is safe to send it again
+func isUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func rebaseRequest(req *http.Request, from, to string) (*http.Request, error) {
+	u := req.URL.String()
+	if !strings.HasPrefix(u, from) {
+		return nil, fmt.Errorf("%s is not under %s", u, from)
+	}
+	next, err := rewindRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := next.URL.UnmarshalBinary([]byte(to + strings.TrimPrefix(u, from))); err != nil {
+		return nil, err
+	}
+	next.Host = ""
+	return next, nil
+}